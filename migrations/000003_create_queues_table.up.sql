@@ -0,0 +1,21 @@
+-- Create queues table
+CREATE TABLE IF NOT EXISTS queues (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    business_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    average_service_minutes INTEGER NOT NULL DEFAULT 15,
+    next_ticket_number INTEGER NOT NULL DEFAULT 1,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_queues_business FOREIGN KEY (business_id) REFERENCES businesses(id) ON DELETE CASCADE
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_queues_business_id ON queues(business_id);
+
+-- Add comments to table
+COMMENT ON TABLE queues IS 'Virtual queues managed by a business';
+COMMENT ON COLUMN queues.average_service_minutes IS 'Average time in minutes to serve one ticket, used for wait estimates';
+COMMENT ON COLUMN queues.next_ticket_number IS 'Sequential number assigned to the next ticket issued in this queue';
@@ -0,0 +1,27 @@
+-- Create tickets table
+CREATE TABLE IF NOT EXISTS tickets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    queue_id UUID NOT NULL,
+    business_id UUID NOT NULL,
+    customer_id UUID NOT NULL,
+    number INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    called_at TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_tickets_queue FOREIGN KEY (queue_id) REFERENCES queues(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tickets_business FOREIGN KEY (business_id) REFERENCES businesses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tickets_customer FOREIGN KEY (customer_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_tickets_queue_number UNIQUE (queue_id, number)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_tickets_queue_status ON tickets(queue_id, status);
+CREATE INDEX IF NOT EXISTS idx_tickets_customer_id ON tickets(customer_id);
+
+-- Add comments to table
+COMMENT ON TABLE tickets IS 'Tickets issued to customers when they join a queue';
+COMMENT ON COLUMN tickets.status IS 'Ticket status: waiting, called, in_service, done, cancelled or no_show';
+COMMENT ON COLUMN tickets.finished_at IS 'Time the ticket reached a final status (done, cancelled or no_show)';
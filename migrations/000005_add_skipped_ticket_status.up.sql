@@ -0,0 +1,2 @@
+-- Tickets can now be skipped by the business and recalled later
+COMMENT ON COLUMN tickets.status IS 'Ticket status: waiting, called, skipped, in_service, done, cancelled or no_show';
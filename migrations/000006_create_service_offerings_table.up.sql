@@ -0,0 +1,29 @@
+-- Create service_offerings table
+CREATE TABLE IF NOT EXISTS service_offerings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    business_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    average_duration_minutes INTEGER NOT NULL,
+    price_cents BIGINT NOT NULL DEFAULT 0,
+    check_in_radius_meters INTEGER NOT NULL DEFAULT 0,
+    late_tolerance_minutes INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_service_offerings_business FOREIGN KEY (business_id) REFERENCES businesses(id) ON DELETE CASCADE
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_service_offerings_business_id ON service_offerings(business_id);
+
+-- Queues may serve a specific offering of the catalog
+ALTER TABLE queues ADD COLUMN IF NOT EXISTS service_id UUID;
+ALTER TABLE queues ADD CONSTRAINT fk_queues_service FOREIGN KEY (service_id) REFERENCES service_offerings(id) ON DELETE SET NULL;
+
+-- Add comments to table
+COMMENT ON TABLE service_offerings IS 'Catalog of services offered by a business';
+COMMENT ON COLUMN service_offerings.price_cents IS 'Price in the smallest currency unit';
+COMMENT ON COLUMN service_offerings.check_in_radius_meters IS 'Maximum distance from the business to accept a check-in (0 disables the check)';
+COMMENT ON COLUMN service_offerings.late_tolerance_minutes IS 'Minutes a called customer may take to show up before being considered late';
+COMMENT ON COLUMN queues.service_id IS 'Optional service offering served by this queue; its average duration drives wait estimates';
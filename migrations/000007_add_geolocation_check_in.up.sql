@@ -0,0 +1,15 @@
+-- Store business coordinates for geolocation check-in
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+-- Record the latest check-in attempt of each ticket
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS check_in_status VARCHAR(20);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS check_in_distance_meters DOUBLE PRECISION;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
+
+-- Add comments to columns
+COMMENT ON COLUMN businesses.latitude IS 'Latitude of the business location in decimal degrees (WGS84)';
+COMMENT ON COLUMN businesses.longitude IS 'Longitude of the business location in decimal degrees (WGS84)';
+COMMENT ON COLUMN tickets.check_in_status IS 'Result of the latest customer check-in: accepted, rejected (NULL if never checked in)';
+COMMENT ON COLUMN tickets.check_in_distance_meters IS 'Great-circle distance between the customer and the business at check-in';
+COMMENT ON COLUMN tickets.checked_in_at IS 'Time of the latest customer check-in attempt';
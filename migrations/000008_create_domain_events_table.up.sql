@@ -0,0 +1,28 @@
+-- Create domain_events table
+CREATE TABLE IF NOT EXISTS domain_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type VARCHAR(50) NOT NULL,
+    business_id UUID NOT NULL,
+    ticket_id UUID,
+    user_id UUID,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    occurred_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_domain_events_business FOREIGN KEY (business_id) REFERENCES businesses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_domain_events_ticket FOREIGN KEY (ticket_id) REFERENCES tickets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_domain_events_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_domain_events_type_occurred_at ON domain_events(event_type, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_domain_events_user_id ON domain_events(user_id);
+CREATE INDEX IF NOT EXISTS idx_domain_events_ticket_id ON domain_events(ticket_id);
+
+-- Speed up the no-show sweeper scan of called tickets
+CREATE INDEX IF NOT EXISTS idx_tickets_called ON tickets(called_at) WHERE status = 'called';
+
+-- Add comments to table
+COMMENT ON TABLE domain_events IS 'Append-only log of domain events consumed by reputation and notifications';
+COMMENT ON COLUMN domain_events.event_type IS 'Event name, e.g. ticket.no_show';
+COMMENT ON COLUMN domain_events.user_id IS 'Customer affected by the event, if any';
+COMMENT ON COLUMN domain_events.payload IS 'Event specific data';
+COMMENT ON COLUMN service_offerings.late_tolerance_minutes IS 'Minutes a called customer may take to show up before being marked as no-show (0 uses the system default)';
@@ -0,0 +1,19 @@
+-- Store the reliability score of each user, derived from their ticket history
+ALTER TABLE users ADD COLUMN IF NOT EXISTS reputation_score INTEGER NOT NULL DEFAULT 100;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS completed_tickets INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS late_cancellations INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS no_shows INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS reputation_updated_at TIMESTAMP WITH TIME ZONE;
+
+-- Flag cancellations made too close to the customer's turn
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS late_cancellation BOOLEAN NOT NULL DEFAULT false;
+
+-- Speed up reputation recalculation
+CREATE INDEX IF NOT EXISTS idx_tickets_customer_status ON tickets(customer_id, status);
+
+-- Add comments to columns
+COMMENT ON COLUMN users.reputation_score IS 'Reliability score from 0 to 100 derived from completed, late-cancelled and no-show tickets';
+COMMENT ON COLUMN users.completed_tickets IS 'Number of tickets served to completion';
+COMMENT ON COLUMN users.late_cancellations IS 'Number of tickets cancelled after the business cancellation deadline';
+COMMENT ON COLUMN users.no_shows IS 'Number of tickets marked as no-show';
+COMMENT ON COLUMN tickets.late_cancellation IS 'True when the customer cancelled after the business cancellation deadline';
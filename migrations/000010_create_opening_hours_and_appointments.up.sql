@@ -0,0 +1,31 @@
+-- Business timezone, used to interpret opening hours
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Create business_opening_hours table
+CREATE TABLE IF NOT EXISTS business_opening_hours (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    business_id UUID NOT NULL,
+    weekday SMALLINT NOT NULL,
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_business_opening_hours_business FOREIGN KEY (business_id) REFERENCES businesses(id) ON DELETE CASCADE,
+    CONSTRAINT chk_business_opening_hours_weekday CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT chk_business_opening_hours_range CHECK (opens_at < closes_at)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_business_opening_hours_business_id ON business_opening_hours(business_id, weekday);
+
+-- Appointments are tickets booked for a time slot
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS scheduled_until TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_queue_scheduled ON tickets(queue_id, scheduled_at) WHERE scheduled_at IS NOT NULL;
+
+-- Add comments to table
+COMMENT ON COLUMN businesses.timezone IS 'IANA timezone in which the opening hours are expressed';
+COMMENT ON TABLE business_opening_hours IS 'Weekly opening hours of a business; a weekday may have several intervals';
+COMMENT ON COLUMN business_opening_hours.weekday IS 'Day of the week: 0 (Sunday) to 6 (Saturday)';
+COMMENT ON COLUMN tickets.scheduled_at IS 'Start of the booked slot for appointments (NULL for walk-in tickets)';
+COMMENT ON COLUMN tickets.scheduled_until IS 'End of the booked slot for appointments';
@@ -0,0 +1,18 @@
+-- Create business_closures table
+CREATE TABLE IF NOT EXISTS business_closures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    business_id UUID NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_business_closures_business FOREIGN KEY (business_id) REFERENCES businesses(id) ON DELETE CASCADE,
+    CONSTRAINT chk_business_closures_range CHECK (starts_at < ends_at)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_business_closures_business_id ON business_closures(business_id, ends_at);
+
+-- Add comments to table
+COMMENT ON TABLE business_closures IS 'Holidays and exceptional closures that override the weekly opening hours';
+COMMENT ON COLUMN business_closures.reason IS 'Optional description shown to customers, e.g. Christmas';
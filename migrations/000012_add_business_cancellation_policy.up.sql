@@ -0,0 +1,7 @@
+-- Cancellation policy: customers may cancel or reschedule an appointment for free
+-- up to this many minutes before its slot starts
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS cancellation_notice_minutes INTEGER NOT NULL DEFAULT 0
+    CONSTRAINT chk_businesses_cancellation_notice CHECK (cancellation_notice_minutes >= 0);
+
+-- Add comments to table
+COMMENT ON COLUMN businesses.cancellation_notice_minutes IS 'Minutes before an appointment after which cancelling counts against the customer reputation and rescheduling is refused (0 allows both until the slot starts)';
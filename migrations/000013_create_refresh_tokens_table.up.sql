@@ -0,0 +1,23 @@
+-- Create refresh_tokens table
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    replaced_by UUID,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+
+-- Add comments to table
+COMMENT ON TABLE refresh_tokens IS 'Issued refresh tokens, rotated on every use';
+COMMENT ON COLUMN refresh_tokens.id IS 'JWT ID (jti) of the refresh token';
+COMMENT ON COLUMN refresh_tokens.family_id IS 'Shared by all tokens rotated from the same login; revoked together when a used token is presented again';
+COMMENT ON COLUMN refresh_tokens.used_at IS 'When the token was exchanged for a new one (NULL while unused)';
+COMMENT ON COLUMN refresh_tokens.replaced_by IS 'JWT ID of the token issued in exchange';
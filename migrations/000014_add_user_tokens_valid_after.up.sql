@@ -0,0 +1,5 @@
+-- Access tokens issued before this time are rejected, ending every session of the user
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tokens_valid_after TIMESTAMP WITH TIME ZONE;
+
+-- Add comments to columns
+COMMENT ON COLUMN users.tokens_valid_after IS 'Tokens issued before this time are no longer accepted (NULL accepts all unexpired tokens)';
@@ -0,0 +1,22 @@
+-- Create verification_codes table
+CREATE TABLE IF NOT EXISTS verification_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    purpose VARCHAR(32) NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    consumed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_verification_codes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_verification_codes_user_purpose ON verification_codes(user_id, purpose, created_at) WHERE consumed_at IS NULL;
+
+-- Add comments to table
+COMMENT ON TABLE verification_codes IS 'Short-lived single-use codes sent to users, e.g. for password reset';
+COMMENT ON COLUMN verification_codes.purpose IS 'What the code proves, e.g. password_reset';
+COMMENT ON COLUMN verification_codes.code_hash IS 'Bcrypt hash of the code; the code itself is never stored';
+COMMENT ON COLUMN verification_codes.attempts IS 'Number of wrong guesses made against the code';
+COMMENT ON COLUMN verification_codes.consumed_at IS 'When the code was used or superseded by a newer one';
@@ -0,0 +1,9 @@
+-- Phone numbers are verified with a code sent over WhatsApp
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Businesses may only accept customers whose phone number is verified
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS require_verified_phone BOOLEAN NOT NULL DEFAULT false;
+
+-- Add comments to columns
+COMMENT ON COLUMN users.phone_verified_at IS 'When the current phone number was verified (NULL while unverified)';
+COMMENT ON COLUMN businesses.require_verified_phone IS 'Whether customers need a verified phone number to join the queues of the business';
@@ -0,0 +1,19 @@
+-- Create login_throttles table
+CREATE TABLE IF NOT EXISTS login_throttles (
+    scope VARCHAR(16) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT pk_login_throttles PRIMARY KEY (scope, key),
+    CONSTRAINT chk_login_throttles_scope CHECK (scope IN ('email', 'ip'))
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_login_throttles_last_failed_at ON login_throttles(last_failed_at);
+
+-- Add comments to table
+COMMENT ON TABLE login_throttles IS 'Failed login attempts per email and per client IP, shared by all API instances';
+COMMENT ON COLUMN login_throttles.key IS 'Normalized email or client IP address';
+COMMENT ON COLUMN login_throttles.failed_attempts IS 'Consecutive failed attempts within the failure window';
+COMMENT ON COLUMN login_throttles.locked_until IS 'No login is attempted for the key before this time';
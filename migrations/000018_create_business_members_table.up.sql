@@ -0,0 +1,19 @@
+-- Create business_members table
+CREATE TABLE IF NOT EXISTS business_members (
+    business_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_business_members PRIMARY KEY (business_id, user_id),
+    CONSTRAINT fk_business_members_business FOREIGN KEY (business_id) REFERENCES businesses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_business_members_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_business_members_role CHECK (role IN ('manager', 'staff'))
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_business_members_user_id ON business_members(user_id);
+
+-- Add comments to table
+COMMENT ON TABLE business_members IS 'Users who work at a business besides its owner';
+COMMENT ON COLUMN business_members.role IS 'Member role that determines the permissions on the business: manager or staff';
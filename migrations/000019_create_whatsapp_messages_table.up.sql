@@ -0,0 +1,28 @@
+-- Create whatsapp_messages table
+CREATE TABLE IF NOT EXISTS whatsapp_messages (
+    id VARCHAR(255) NOT NULL,
+    recipient VARCHAR(50) NOT NULL,
+    type VARCHAR(16),
+    template_name VARCHAR(255),
+    status VARCHAR(16) NOT NULL,
+    error_code INTEGER,
+    error_title TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_whatsapp_messages PRIMARY KEY (id),
+    CONSTRAINT chk_whatsapp_messages_status CHECK (status IN ('accepted', 'sent', 'delivered', 'read', 'failed'))
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_whatsapp_messages_recipient ON whatsapp_messages(recipient);
+
+-- Add comments to table
+COMMENT ON TABLE whatsapp_messages IS 'Outbound WhatsApp messages and their delivery status reported by Meta';
+COMMENT ON COLUMN whatsapp_messages.id IS 'Message ID (wamid) returned by the Graph API when the message was sent';
+COMMENT ON COLUMN whatsapp_messages.type IS 'Message type (text or template); NULL when a status arrived before the send was recorded';
+COMMENT ON COLUMN whatsapp_messages.status IS 'Latest delivery status: accepted, sent, delivered, read or failed';
+COMMENT ON COLUMN whatsapp_messages.error_code IS 'Graph API error code reported with a failed status';
@@ -0,0 +1,29 @@
+-- Create notifications table
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    recipient VARCHAR(50) NOT NULL,
+    message JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    message_id VARCHAR(255),
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_notifications_status CHECK (status IN ('pending', 'sent', 'dead')),
+    CONSTRAINT chk_notifications_attempts CHECK (attempts >= 0)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_notifications_dead ON notifications(updated_at) WHERE status = 'dead';
+
+-- Add comments to table
+COMMENT ON TABLE notifications IS 'Outbox of WhatsApp messages, sent in the background and retried with exponential backoff';
+COMMENT ON COLUMN notifications.message IS 'Message to send, in the format of the send message request';
+COMMENT ON COLUMN notifications.status IS 'Delivery state: pending (waiting to be sent or retried), sent, or dead (gave up after the last attempt)';
+COMMENT ON COLUMN notifications.attempts IS 'Number of send attempts started so far';
+COMMENT ON COLUMN notifications.next_attempt_at IS 'Earliest time of the next attempt; pushed forward while an attempt is in flight';
+COMMENT ON COLUMN notifications.last_error IS 'Error of the latest failed attempt';
+COMMENT ON COLUMN notifications.message_id IS 'Message ID (wamid) returned by the Graph API once sent';
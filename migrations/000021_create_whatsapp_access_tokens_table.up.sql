@@ -0,0 +1,16 @@
+-- Create whatsapp_access_tokens table
+CREATE TABLE IF NOT EXISTS whatsapp_access_tokens (
+    phone_number_id VARCHAR(64) NOT NULL,
+    access_token TEXT NOT NULL,
+    seed_token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_whatsapp_access_tokens PRIMARY KEY (phone_number_id)
+);
+
+-- Add comments to table
+COMMENT ON TABLE whatsapp_access_tokens IS 'Latest WhatsApp access token obtained by extending the configured one, kept across restarts';
+COMMENT ON COLUMN whatsapp_access_tokens.phone_number_id IS 'WhatsApp Business phone number the token sends messages for';
+COMMENT ON COLUMN whatsapp_access_tokens.seed_token_hash IS 'SHA-256 of the configured WHATSAPP_ACCESS_TOKEN the token was extended from; a different configured token replaces it';
+COMMENT ON COLUMN whatsapp_access_tokens.expires_at IS 'Expiry of the token; NULL when unknown';
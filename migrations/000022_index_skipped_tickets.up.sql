@@ -0,0 +1,4 @@
+-- Skipped tickets are now swept as no-shows too; speed up the sweeper scan of them
+CREATE INDEX IF NOT EXISTS idx_tickets_skipped ON tickets(updated_at) WHERE status = 'skipped';
+
+COMMENT ON COLUMN service_offerings.late_tolerance_minutes IS 'Minutes a called or skipped customer may take to show up before being marked as no-show (0 uses the system default)';
@@ -0,0 +1,5 @@
+-- Phone verification codes prove the number they were sent to, which may change before the code is used
+ALTER TABLE verification_codes ADD COLUMN IF NOT EXISTS phone VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Add comments to columns
+COMMENT ON COLUMN verification_codes.phone IS 'Phone number the code was sent to (empty for codes sent before it was recorded)';
@@ -0,0 +1,18 @@
+-- Messages about a ticket are linked to it and to its business, so business members can follow their delivery
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS business_id UUID
+    CONSTRAINT fk_notifications_business REFERENCES businesses(id) ON DELETE SET NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS ticket_id UUID
+    CONSTRAINT fk_notifications_ticket REFERENCES tickets(id) ON DELETE SET NULL;
+ALTER TABLE whatsapp_messages ADD COLUMN IF NOT EXISTS business_id UUID
+    CONSTRAINT fk_whatsapp_messages_business REFERENCES businesses(id) ON DELETE SET NULL;
+ALTER TABLE whatsapp_messages ADD COLUMN IF NOT EXISTS ticket_id UUID
+    CONSTRAINT fk_whatsapp_messages_ticket REFERENCES tickets(id) ON DELETE SET NULL;
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_whatsapp_messages_business ON whatsapp_messages(business_id, created_at) WHERE business_id IS NOT NULL;
+
+-- Add comments to columns
+COMMENT ON COLUMN notifications.business_id IS 'Business the notification is about (NULL for account messages such as verification codes)';
+COMMENT ON COLUMN notifications.ticket_id IS 'Ticket the notification is about, if any';
+COMMENT ON COLUMN whatsapp_messages.business_id IS 'Business the message is about; its members can see the message and its delivery status';
+COMMENT ON COLUMN whatsapp_messages.ticket_id IS 'Ticket the message is about, if any';
@@ -0,0 +1,9 @@
+-- Inbound WhatsApp messages are matched to their sender by phone digits; store them so the lookup can use an index
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_digits VARCHAR(50)
+    GENERATED ALWAYS AS (regexp_replace(phone, '[^0-9]', '', 'g')) STORED;
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_users_verified_phone_digits ON users(phone_digits, phone_verified_at DESC) WHERE phone_verified_at IS NOT NULL;
+
+-- Add comments to columns
+COMMENT ON COLUMN users.phone_digits IS 'Digits of the phone number, without the formatting it was entered with';
@@ -6,10 +6,17 @@ import (
 	"easy-queue-go/src/internal/handlers"
 	"easy-queue-go/src/internal/infra/database"
 	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
 	"easy-queue-go/src/internal/repositories"
 	"easy-queue-go/src/internal/routes"
 	"easy-queue-go/src/internal/services"
 	"easy-queue-go/src/internal/tracing"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -80,29 +87,44 @@ func main() {
 
 	// Initialize dependencies
 	userRepo := repositories.NewUserRepository(pool)
-	userService := services.NewUserService(userRepo)
-	userHandler := handlers.NewUserHandler(userService)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(pool)
+	verificationCodeRepo := repositories.NewVerificationCodeRepository(pool)
 
 	// Initialize business dependencies
 	businessRepo := repositories.NewBusinessRepository(pool)
-	businessService := services.NewBusinessService(businessRepo, userRepo)
+	serviceOfferingRepo := repositories.NewServiceOfferingRepository(pool)
+	openingHoursRepo := repositories.NewOpeningHoursRepository(pool)
+	closureRepo := repositories.NewBusinessClosureRepository(pool)
+	businessMemberRepo := repositories.NewBusinessMemberRepository(pool)
+	businessService := services.NewBusinessService(businessRepo, userRepo, serviceOfferingRepo, openingHoursRepo, closureRepo, businessMemberRepo)
 	businessHandler := handlers.NewBusinessHandler(businessService)
+	authorizationService := services.NewAuthorizationService(businessRepo, businessMemberRepo)
 
-	// Initialize auth service
-	authService := services.NewAuthService(userRepo, services.AuthServiceConfig{
-		JWTSecret:       configs.JWT.Secret,
-		AccessTokenTTL:  configs.JWT.AccessTokenTTL,
-		RefreshTokenTTL: configs.JWT.RefreshTokenTTL,
-	})
-
-	// Initialize auth handler
-	authHandler := handlers.NewAuthHandler(authService)
+	// Initialize queue dependencies
+	queueRepo := repositories.NewQueueRepository(pool)
+	ticketRepo := repositories.NewTicketRepository(pool)
+	reputationService := services.NewReputationService(ticketRepo, userRepo)
+	queueService := services.NewQueueService(queueRepo, ticketRepo, businessRepo, serviceOfferingRepo, reputationService)
+	queueHandler := handlers.NewQueueHandler(queueService)
+	ticketService := services.NewTicketService(ticketRepo, queueRepo, businessRepo, userRepo, serviceOfferingRepo, openingHoursRepo, closureRepo, reputationService)
+	ticketHandler := handlers.NewTicketHandler(ticketService)
 
 	// Initialize WhatsApp service and handler
+	var whatsappService services.WhatsAppService
 	var whatsappHandler *handlers.WhatsAppHandler
+	var whatsappTokenManager *services.WhatsAppTokenManager
+	var notificationService services.NotificationService
+	var notificationDispatcher *services.NotificationDispatcher
 	if configs.WhatsApp != nil {
-		whatsappService := services.NewWhatsAppService(configs.WhatsApp)
+		whatsappMessageRepo := repositories.NewWhatsAppMessageRepository(pool)
+		whatsappTokenRepo := repositories.NewWhatsAppTokenRepository(pool)
+		whatsappTokenManager = services.NewWhatsAppTokenManager(configs.WhatsApp, whatsappTokenRepo)
+		notificationRepo := repositories.NewNotificationRepository(pool)
+		notificationService = services.NewNotificationService(notificationRepo)
+		whatsappCommandService := services.NewWhatsAppCommandService(userRepo, ticketService, notificationService)
+		whatsappService = services.NewWhatsAppService(configs.WhatsApp, whatsappMessageRepo, whatsappCommandService, whatsappTokenManager)
 		whatsappHandler = handlers.NewWhatsAppHandler(whatsappService)
+		notificationDispatcher = services.NewNotificationDispatcher(notificationRepo, whatsappService, configs.Notification)
 		log.Info(ctx, "WhatsApp integration initialized",
 			zap.String("phone_number_id", configs.WhatsApp.PhoneNumberID),
 		)
@@ -112,12 +134,92 @@ func main() {
 		whatsappHandler = handlers.NewWhatsAppHandler(nil)
 	}
 
+	// Initialize user dependencies
+	phoneVerificationService := services.NewPhoneVerificationService(userRepo, verificationCodeRepo, notificationService, configs.PhoneVerification)
+	userService := services.NewUserService(userRepo, refreshTokenRepo, phoneVerificationService)
+	userHandler := handlers.NewUserHandler(userService, phoneVerificationService)
+
+	// Create the first administrator, if configured
+	if configs.BootstrapAdmin != nil {
+		if err := userService.BootstrapAdmin(ctx, &models.AdminCreateUserRequest{
+			Email:    configs.BootstrapAdmin.Email,
+			Password: configs.BootstrapAdmin.Password,
+			Phone:    configs.BootstrapAdmin.Phone,
+			Roles:    []models.UserRole{models.RoleAdmin},
+		}); err != nil {
+			log.Fatal(ctx, "Failed to create bootstrap admin", zap.Error(err))
+		}
+	}
+
+	// Initialize auth service
+	loginThrottleRepo := repositories.NewLoginThrottleRepository(pool)
+	loginThrottleService := services.NewLoginThrottleService(loginThrottleRepo, configs.LoginThrottle)
+	tokenKeys, err := services.LoadTokenKeySet(configs.JWT)
+	if err != nil {
+		log.Fatal(ctx, "Failed to load JWT keys", zap.Error(err))
+	}
+	authService := services.NewAuthService(userRepo, refreshTokenRepo, loginThrottleService, services.AuthServiceConfig{
+		Keys:            tokenKeys,
+		AccessTokenTTL:  configs.JWT.AccessTokenTTL,
+		RefreshTokenTTL: configs.JWT.RefreshTokenTTL,
+	})
+
+	// Initialize auth handler
+	passwordResetService := services.NewPasswordResetService(userRepo, verificationCodeRepo, refreshTokenRepo, notificationService, loginThrottleService, configs.PasswordReset)
+	authHandler := handlers.NewAuthHandler(authService, passwordResetService)
+
 	// Setup router
-	router := routes.SetupRouter(tracingConfig.ServiceName, userHandler, authHandler, businessHandler, whatsappHandler, authService)
+	router, err := routes.SetupRouter(tracingConfig.ServiceName, userHandler, authHandler, businessHandler, queueHandler, ticketHandler, whatsappHandler, authService, authorizationService, configs.Server.TrustedProxies)
+	if err != nil {
+		log.Fatal(ctx, "Failed to setup router", zap.Error(err))
+	}
+
+	// Start background workers
+	noShowSweeper := services.NewNoShowSweeper(ticketRepo, reputationService, configs.NoShow.SweepInterval, configs.NoShow.DefaultTolerance)
+	noShowSweeper.Start(ctx)
+	if whatsappTokenManager != nil {
+		if err := whatsappTokenManager.Start(ctx); err != nil {
+			log.Fatal(ctx, "Failed to start WhatsApp token manager", zap.Error(err))
+		}
+	}
+	if notificationDispatcher != nil {
+		notificationDispatcher.Start(ctx)
+	}
 
 	// Start server
-	log.Info(ctx, "Starting server on port 8080")
-	if err := router.Run(":8080"); err != nil {
-		log.Fatal(ctx, "Failed to start server", zap.Error(err))
+	server := &http.Server{
+		Addr:    ":8080",
+		Handler: router,
+	}
+
+	go func() {
+		log.Info(ctx, "Starting server on port 8080")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(ctx, "Failed to start server", zap.Error(err))
+		}
+	}()
+
+	// Wait for a termination signal, then shut down gracefully
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Info(ctx, "Shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error(ctx, "Server forced to shutdown", zap.Error(err))
 	}
+
+	noShowSweeper.Stop()
+	if notificationDispatcher != nil {
+		notificationDispatcher.Stop()
+	}
+	if whatsappTokenManager != nil {
+		whatsappTokenManager.Stop()
+	}
+
+	log.Info(ctx, "Server stopped")
 }
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+)
+
+// BootstrapAdminConfig holds the account created as the first administrator
+type BootstrapAdminConfig struct {
+	Email    string
+	Password string
+	Phone    string
+}
+
+// LoadBootstrapAdminConfig loads the bootstrap admin from environment variables.
+// It returns nil if BOOTSTRAP_ADMIN_EMAIL is not set.
+func LoadBootstrapAdminConfig() (*BootstrapAdminConfig, error) {
+	email := getEnv("BOOTSTRAP_ADMIN_EMAIL", "")
+	if email == "" {
+		return nil, nil
+	}
+
+	password := getEnv("BOOTSTRAP_ADMIN_PASSWORD", "")
+	if len(password) < 6 {
+		return nil, fmt.Errorf("BOOTSTRAP_ADMIN_PASSWORD must be at least 6 characters when BOOTSTRAP_ADMIN_EMAIL is set")
+	}
+
+	phone := getEnv("BOOTSTRAP_ADMIN_PHONE", "")
+	if phone == "" {
+		return nil, fmt.Errorf("BOOTSTRAP_ADMIN_PHONE is required when BOOTSTRAP_ADMIN_EMAIL is set")
+	}
+
+	return &BootstrapAdminConfig{
+		Email:    email,
+		Password: password,
+		Phone:    phone,
+	}, nil
+}
@@ -6,9 +6,16 @@ import (
 )
 
 type Configs struct {
-	DB       *DBConfig
-	JWT      *JWTConfig
-	WhatsApp *WhatsAppConfig
+	DB                *DBConfig
+	JWT               *JWTConfig
+	WhatsApp          *WhatsAppConfig
+	NoShow            *NoShowConfig
+	Notification      *NotificationConfig
+	PasswordReset     *PasswordResetConfig
+	PhoneVerification *PhoneVerificationConfig
+	LoginThrottle     *LoginThrottleConfig
+	BootstrapAdmin    *BootstrapAdminConfig
+	Server            *ServerConfig
 }
 
 // InitializeConfigs initializes the configs
@@ -20,15 +27,57 @@ func InitializeConfigs() *Configs {
 		log.Fatalf("Failed to load JWT config: %v", err)
 	}
 
+	loginThrottleConfig, err := LoadLoginThrottleConfig()
+	if err != nil {
+		log.Fatalf("Failed to load login throttle config: %v", err)
+	}
+
+	noShowConfig, err := LoadNoShowConfig()
+	if err != nil {
+		log.Fatalf("Failed to load no-show config: %v", err)
+	}
+
+	notificationConfig, err := LoadNotificationConfig()
+	if err != nil {
+		log.Fatalf("Failed to load notification config: %v", err)
+	}
+
+	passwordResetConfig, err := LoadPasswordResetConfig()
+	if err != nil {
+		log.Fatalf("Failed to load password reset config: %v", err)
+	}
+
+	phoneVerificationConfig, err := LoadPhoneVerificationConfig()
+	if err != nil {
+		log.Fatalf("Failed to load phone verification config: %v", err)
+	}
+
+	bootstrapAdminConfig, err := LoadBootstrapAdminConfig()
+	if err != nil {
+		log.Fatalf("Failed to load bootstrap admin config: %v", err)
+	}
+
+	serverConfig, err := LoadServerConfig()
+	if err != nil {
+		log.Fatalf("Failed to load server config: %v", err)
+	}
+
 	whatsappConfig, err := LoadWhatsAppConfig()
 	if err != nil {
 		log.Printf("Warning: Failed to load WhatsApp config: %v (WhatsApp features will be disabled)", err)
 	}
 
 	return &Configs{
-		DB:       dbConfigs,
-		JWT:      jwtConfig,
-		WhatsApp: whatsappConfig,
+		DB:                dbConfigs,
+		JWT:               jwtConfig,
+		WhatsApp:          whatsappConfig,
+		NoShow:            noShowConfig,
+		Notification:      notificationConfig,
+		PasswordReset:     passwordResetConfig,
+		PhoneVerification: phoneVerificationConfig,
+		LoginThrottle:     loginThrottleConfig,
+		BootstrapAdmin:    bootstrapAdminConfig,
+		Server:            serverConfig,
 	}
 }
 
@@ -7,16 +7,38 @@ import (
 
 // JWTConfig holds the JWT configuration
 type JWTConfig struct {
-	Secret          string
-	AccessTokenTTL  time.Duration
-	RefreshTokenTTL time.Duration
+	Secret            string     // HS256 secret; optional once signing keys are configured
+	KeysDir           string     // Directory of PEM keys named <kid>.pem
+	ActiveKeyID       string     // Key ID of the key in KeysDir that signs new tokens
+	LegacySecretUntil *time.Time // With KeysDir, tokens without a kid are verified with Secret until this time, nil for never
+	AccessTokenTTL    time.Duration
+	RefreshTokenTTL   time.Duration
 }
 
 // LoadJWTConfig loads the JWT configuration from environment variables
 func LoadJWTConfig() (*JWTConfig, error) {
 	secret := getEnv("JWT_SECRET", "")
-	if secret == "" {
-		return nil, fmt.Errorf("JWT_SECRET environment variable is required")
+	keysDir := getEnv("JWT_KEYS_DIR", "")
+	activeKeyID := getEnv("JWT_ACTIVE_KEY_ID", "")
+
+	if keysDir == "" && secret == "" {
+		return nil, fmt.Errorf("JWT_KEYS_DIR or JWT_SECRET environment variable is required")
+	}
+	if keysDir != "" && activeKeyID == "" {
+		return nil, fmt.Errorf("JWT_ACTIVE_KEY_ID is required when JWT_KEYS_DIR is set")
+	}
+
+	// Parse the end of the HS256 fallback after moving to signing keys (default: no fallback)
+	var legacySecretUntil *time.Time
+	if value := getEnv("JWT_LEGACY_SECRET_UNTIL", ""); value != "" {
+		until, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT_LEGACY_SECRET_UNTIL: %w", err)
+		}
+		if secret == "" {
+			return nil, fmt.Errorf("JWT_SECRET is required when JWT_LEGACY_SECRET_UNTIL is set")
+		}
+		legacySecretUntil = &until
 	}
 
 	// Parse access token TTL (default: 15 minutes)
@@ -34,8 +56,11 @@ func LoadJWTConfig() (*JWTConfig, error) {
 	}
 
 	return &JWTConfig{
-		Secret:          secret,
-		AccessTokenTTL:  accessTTL,
-		RefreshTokenTTL: refreshTTL,
+		Secret:            secret,
+		KeysDir:           keysDir,
+		ActiveKeyID:       activeKeyID,
+		LegacySecretUntil: legacySecretUntil,
+		AccessTokenTTL:    accessTTL,
+		RefreshTokenTTL:   refreshTTL,
 	}, nil
 }
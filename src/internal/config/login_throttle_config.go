@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// LoginThrottleConfig holds the configuration of login brute-force protection
+type LoginThrottleConfig struct {
+	MaxAttemptsPerEmail int
+	MaxAttemptsPerIP    int
+	BaseDelay           time.Duration
+	LockoutDuration     time.Duration
+	FailureWindow       time.Duration
+}
+
+// LoadLoginThrottleConfig loads the login throttle configuration from environment variables
+func LoadLoginThrottleConfig() (*LoginThrottleConfig, error) {
+	// Parse failed attempts per email before the account is locked (default: 5)
+	maxPerEmailStr := getEnv("LOGIN_MAX_ATTEMPTS_PER_EMAIL", "5")
+	maxPerEmail, err := strconv.Atoi(maxPerEmailStr)
+	if err != nil || maxPerEmail <= 0 {
+		return nil, fmt.Errorf("invalid LOGIN_MAX_ATTEMPTS_PER_EMAIL: must be a positive integer")
+	}
+
+	// Parse failed attempts per client IP before the IP is locked out (default: 20)
+	maxPerIPStr := getEnv("LOGIN_MAX_ATTEMPTS_PER_IP", "20")
+	maxPerIP, err := strconv.Atoi(maxPerIPStr)
+	if err != nil || maxPerIP <= 0 {
+		return nil, fmt.Errorf("invalid LOGIN_MAX_ATTEMPTS_PER_IP: must be a positive integer")
+	}
+
+	// Parse delay after the first failed attempt, doubled after each further one (default: 1 second)
+	baseDelayStr := getEnv("LOGIN_BACKOFF_BASE_DELAY", "1s")
+	baseDelay, err := time.ParseDuration(baseDelayStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_BACKOFF_BASE_DELAY: %w", err)
+	}
+	if baseDelay < 0 {
+		return nil, fmt.Errorf("LOGIN_BACKOFF_BASE_DELAY must not be negative")
+	}
+
+	// Parse lockout duration once the threshold is reached (default: 15 minutes)
+	lockoutStr := getEnv("LOGIN_LOCKOUT_DURATION", "15m")
+	lockout, err := time.ParseDuration(lockoutStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_DURATION: %w", err)
+	}
+	if lockout <= 0 {
+		return nil, fmt.Errorf("LOGIN_LOCKOUT_DURATION must be positive")
+	}
+
+	// Parse how long failed attempts are remembered (default: 15 minutes)
+	windowStr := getEnv("LOGIN_FAILURE_WINDOW", "15m")
+	window, err := time.ParseDuration(windowStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_FAILURE_WINDOW: %w", err)
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("LOGIN_FAILURE_WINDOW must be positive")
+	}
+
+	return &LoginThrottleConfig{
+		MaxAttemptsPerEmail: maxPerEmail,
+		MaxAttemptsPerIP:    maxPerIP,
+		BaseDelay:           baseDelay,
+		LockoutDuration:     lockout,
+		FailureWindow:       window,
+	}, nil
+}
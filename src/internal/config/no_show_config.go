@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// NoShowConfig holds the configuration of the no-show sweeper
+type NoShowConfig struct {
+	SweepInterval    time.Duration
+	DefaultTolerance time.Duration
+}
+
+// LoadNoShowConfig loads the no-show sweeper configuration from environment variables
+func LoadNoShowConfig() (*NoShowConfig, error) {
+	// Parse sweep interval (default: 1 minute)
+	intervalStr := getEnv("NO_SHOW_SWEEP_INTERVAL", "1m")
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NO_SHOW_SWEEP_INTERVAL: %w", err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("NO_SHOW_SWEEP_INTERVAL must be positive")
+	}
+
+	// Parse tolerance for services without one (default: 10 minutes)
+	toleranceStr := getEnv("NO_SHOW_DEFAULT_TOLERANCE", "10m")
+	tolerance, err := time.ParseDuration(toleranceStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NO_SHOW_DEFAULT_TOLERANCE: %w", err)
+	}
+
+	return &NoShowConfig{
+		SweepInterval:    interval,
+		DefaultTolerance: tolerance,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// NotificationConfig holds the configuration of the outbound notification dispatcher
+type NotificationConfig struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// LoadNotificationConfig loads the notification dispatcher configuration from environment variables
+func LoadNotificationConfig() (*NotificationConfig, error) {
+	// Parse how often the outbox is checked for due notifications (default: 5 seconds)
+	intervalStr := getEnv("NOTIFICATION_POLL_INTERVAL", "5s")
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NOTIFICATION_POLL_INTERVAL: %w", err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("NOTIFICATION_POLL_INTERVAL must be positive")
+	}
+
+	// Parse notifications sent per poll (default: 20)
+	batchSizeStr := getEnv("NOTIFICATION_BATCH_SIZE", "20")
+	batchSize, err := strconv.Atoi(batchSizeStr)
+	if err != nil || batchSize <= 0 {
+		return nil, fmt.Errorf("invalid NOTIFICATION_BATCH_SIZE: must be a positive integer")
+	}
+
+	// Parse send attempts before a notification is dead-lettered (default: 8)
+	maxAttemptsStr := getEnv("NOTIFICATION_MAX_ATTEMPTS", "8")
+	maxAttempts, err := strconv.Atoi(maxAttemptsStr)
+	if err != nil || maxAttempts <= 0 {
+		return nil, fmt.Errorf("invalid NOTIFICATION_MAX_ATTEMPTS: must be a positive integer")
+	}
+
+	// Parse wait after the first failed attempt, doubled after each further one (default: 10 seconds)
+	baseDelayStr := getEnv("NOTIFICATION_RETRY_BASE_DELAY", "10s")
+	baseDelay, err := time.ParseDuration(baseDelayStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NOTIFICATION_RETRY_BASE_DELAY: %w", err)
+	}
+	if baseDelay <= 0 {
+		return nil, fmt.Errorf("NOTIFICATION_RETRY_BASE_DELAY must be positive")
+	}
+
+	// Parse the longest wait between two attempts (default: 30 minutes)
+	maxDelayStr := getEnv("NOTIFICATION_RETRY_MAX_DELAY", "30m")
+	maxDelay, err := time.ParseDuration(maxDelayStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NOTIFICATION_RETRY_MAX_DELAY: %w", err)
+	}
+	if maxDelay < baseDelay {
+		return nil, fmt.Errorf("NOTIFICATION_RETRY_MAX_DELAY must not be shorter than NOTIFICATION_RETRY_BASE_DELAY")
+	}
+
+	return &NotificationConfig{
+		PollInterval:   interval,
+		BatchSize:      batchSize,
+		MaxAttempts:    maxAttempts,
+		RetryBaseDelay: baseDelay,
+		RetryMaxDelay:  maxDelay,
+	}, nil
+}
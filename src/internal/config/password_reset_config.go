@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PasswordResetConfig holds the configuration of password reset codes
+type PasswordResetConfig struct {
+	CodeTTL          time.Duration
+	MaxAttempts      int
+	TemplateName     string
+	TemplateLanguage string
+}
+
+// LoadPasswordResetConfig loads the password reset configuration from environment variables
+func LoadPasswordResetConfig() (*PasswordResetConfig, error) {
+	// Parse code TTL (default: 10 minutes)
+	ttlStr := getEnv("PASSWORD_RESET_CODE_TTL", "10m")
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_RESET_CODE_TTL: %w", err)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("PASSWORD_RESET_CODE_TTL must be positive")
+	}
+
+	// Parse wrong guesses allowed per code (default: 5)
+	maxAttemptsStr := getEnv("PASSWORD_RESET_MAX_ATTEMPTS", "5")
+	maxAttempts, err := strconv.Atoi(maxAttemptsStr)
+	if err != nil || maxAttempts <= 0 {
+		return nil, fmt.Errorf("invalid PASSWORD_RESET_MAX_ATTEMPTS: must be a positive integer")
+	}
+
+	return &PasswordResetConfig{
+		CodeTTL:          ttl,
+		MaxAttempts:      maxAttempts,
+		TemplateName:     getEnv("WHATSAPP_PASSWORD_RESET_TEMPLATE", "password_reset"),
+		TemplateLanguage: getEnv("WHATSAPP_TEMPLATE_LANGUAGE", "en_US"),
+	}, nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PhoneVerificationConfig holds the configuration of phone verification codes
+type PhoneVerificationConfig struct {
+	CodeTTL          time.Duration
+	MaxAttempts      int
+	TemplateName     string
+	TemplateLanguage string
+}
+
+// LoadPhoneVerificationConfig loads the phone verification configuration from environment variables
+func LoadPhoneVerificationConfig() (*PhoneVerificationConfig, error) {
+	// Parse code TTL (default: 10 minutes)
+	ttlStr := getEnv("PHONE_VERIFICATION_CODE_TTL", "10m")
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PHONE_VERIFICATION_CODE_TTL: %w", err)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("PHONE_VERIFICATION_CODE_TTL must be positive")
+	}
+
+	// Parse wrong guesses allowed per code (default: 5)
+	maxAttemptsStr := getEnv("PHONE_VERIFICATION_MAX_ATTEMPTS", "5")
+	maxAttempts, err := strconv.Atoi(maxAttemptsStr)
+	if err != nil || maxAttempts <= 0 {
+		return nil, fmt.Errorf("invalid PHONE_VERIFICATION_MAX_ATTEMPTS: must be a positive integer")
+	}
+
+	return &PhoneVerificationConfig{
+		CodeTTL:          ttl,
+		MaxAttempts:      maxAttempts,
+		TemplateName:     getEnv("WHATSAPP_PHONE_VERIFICATION_TEMPLATE", "phone_verification"),
+		TemplateLanguage: getEnv("WHATSAPP_TEMPLATE_LANGUAGE", "en_US"),
+	}, nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ServerConfig holds the configuration of the HTTP server
+type ServerConfig struct {
+	// TrustedProxies are the IPs or CIDRs whose X-Forwarded-For header is trusted to carry the client IP.
+	// Empty means no proxy is trusted and the client IP is always the remote address.
+	TrustedProxies []string
+}
+
+// LoadServerConfig loads the server configuration from environment variables
+func LoadServerConfig() (*ServerConfig, error) {
+	// Parse the comma-separated trusted proxies (default: none)
+	var trustedProxies []string
+	for _, proxy := range strings.Split(getEnv("TRUSTED_PROXIES", ""), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %q is not an IP or CIDR", proxy)
+			}
+		}
+		trustedProxies = append(trustedProxies, proxy)
+	}
+
+	return &ServerConfig{
+		TrustedProxies: trustedProxies,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetAvailability godoc
+// @Summary Lists free appointment slots
+// @Description Returns the appointment slots of a queue that are still free on a day, based on the business opening hours and the service duration
+// @Tags appointments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param queueId path string true "Queue ID (UUID)"
+// @Param date query string true "Day in the business timezone (YYYY-MM-DD)"
+// @Success 200 {object} models.AvailabilityResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /queues/{queueId}/availability [get]
+func (h *TicketHandler) GetAvailability(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	queueID, ok := parseUUIDParam(c, "queueId")
+	if !ok {
+		return
+	}
+
+	availability, err := h.ticketService.GetAvailability(ctx, queueID, c.Query("date"))
+	if err != nil {
+		log.Error(ctx, "Failed to get availability", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get availability")
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// BookAppointment godoc
+// @Summary Books an appointment
+// @Description Reserves a time slot in a queue for the authenticated customer. The appointment is merged with walk-in tickets once its slot starts.
+// @Tags appointments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param queueId path string true "Queue ID (UUID)"
+// @Param appointment body models.BookAppointmentRequest true "Appointment slot"
+// @Success 201 {object} models.TicketPositionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /queues/{queueId}/appointments [post]
+func (h *TicketHandler) BookAppointment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	queueID, ok := parseUUIDParam(c, "queueId")
+	if !ok {
+		return
+	}
+
+	var req models.BookAppointmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	position, err := h.ticketService.BookAppointment(ctx, jwtClaims.UserID, queueID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to book appointment", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to book appointment")
+		return
+	}
+
+	log.Info(ctx, "Appointment booked via HTTP",
+		zap.String("ticket_id", position.Ticket.ID.String()),
+	)
+
+	c.JSON(http.StatusCreated, position)
+}
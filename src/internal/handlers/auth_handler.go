@@ -4,7 +4,10 @@ import (
 	"easy-queue-go/src/internal/log"
 	"easy-queue-go/src/internal/models"
 	"easy-queue-go/src/internal/services"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -12,13 +15,15 @@ import (
 
 // AuthHandler handles authentication-related HTTP requests
 type AuthHandler struct {
-	authService services.AuthService
+	authService          services.AuthService
+	passwordResetService services.PasswordResetService
 }
 
 // NewAuthHandler creates a new AuthHandler instance
-func NewAuthHandler(authService services.AuthService) *AuthHandler {
+func NewAuthHandler(authService services.AuthService, passwordResetService services.PasswordResetService) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:          authService,
+		passwordResetService: passwordResetService,
 	}
 }
 
@@ -32,7 +37,10 @@ func NewAuthHandler(authService services.AuthService) *AuthHandler {
 // @Success 200 {object} models.LoginResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 423 {object} map[string]interface{} "Account temporarily locked; retry_after gives the seconds to wait"
+// @Failure 429 {object} map[string]interface{} "Too many login attempts; retry_after gives the seconds to wait"
 // @Failure 500 {object} map[string]string
+// @Header 423,429 {integer} Retry-After "Seconds until a new login attempt is accepted"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -46,9 +54,15 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Login(ctx, &req)
+	response, err := h.authService.Login(ctx, &req, c.ClientIP())
 	if err != nil {
 		log.Error(ctx, "Login failed", zap.Error(err))
+
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			respondThrottled(c, throttled)
+			return
+		}
 		
 		// Return 401 for authentication failures
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -65,9 +79,21 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetJWKS publishes the token verification keys
+// @Summary JSON Web Key Set
+// @Description Returns the public keys that verify access and refresh tokens, selected by the kid header of a token. Keys are added before they start signing and kept until the tokens they signed have expired, so verifiers can cache the set and refetch it when they meet an unknown kid.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.JSONWebKeySet
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authService.JWKS())
+}
+
 // RefreshToken handles token refresh requests
 // @Summary Refresh access token
-// @Description Generate new access and refresh tokens using a valid refresh token
+// @Description Generate new access and refresh tokens using a valid refresh token. Each refresh token can be used only once; presenting a used one again revokes every token issued since the same login and fails with "refresh token reuse detected", after which the client must log in again.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -104,3 +130,243 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// Logout handles session logout requests
+// @Summary Log out
+// @Description Revoke the given refresh token and every token rotated from the same login. Access tokens already issued stay valid until they expire.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.LogoutRequest true "Refresh token of the session"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid logout request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.authService.Logout(ctx, &req); err != nil {
+		log.Error(ctx, "Logout failed", zap.Error(err))
+
+		if err.Error() == "invalid refresh token" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to log out",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll handles requests to end every session of the authenticated user
+// @Summary Log out of all devices
+// @Description Revoke every refresh token of the authenticated user and reject all access tokens issued until now
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.LogoutAll(ctx, jwtClaims.UserID); err != nil {
+		log.Error(ctx, "Logout of all devices failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to log out of all devices",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ChangePassword handles requests of the authenticated user to change their password
+// @Summary Change password
+// @Description Set a new password after checking the current one. Every other session of the user is ended; the tokens in the response replace the ones used for this request. Wrong current passwords count as failed logins.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} models.LoginResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 423 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /users/me/password [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid password change request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	response, err := h.authService.ChangePassword(ctx, jwtClaims.UserID, &req, c.ClientIP())
+	if err != nil {
+		log.Error(ctx, "Password change failed", zap.Error(err))
+
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			respondThrottled(c, throttled)
+			return
+		}
+
+		if err.Error() == "current password is incorrect" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to change password",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RequestPasswordReset handles requests to send a password reset code
+// @Summary Request a password reset code
+// @Description Send a short-lived, single-use reset code over WhatsApp to the verified phone of the account with the given email. The response is the same whether or not the email is registered or its phone verified.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.RequestPasswordResetRequest true "Account email"
+// @Success 202 "Accepted"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /auth/password-reset/request [post]
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.RequestPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid password reset request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.passwordResetService.RequestReset(ctx, &req); err != nil {
+		log.Error(ctx, "Password reset request failed", zap.Error(err))
+
+		if err.Error() == "password reset is not available" {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to send password reset code",
+		})
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ConfirmPasswordReset handles requests to set a new password with a reset code
+// @Summary Reset the password
+// @Description Verify a password reset code and set a new password. Every session of the user is ended.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.ConfirmPasswordResetRequest true "Reset code and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 423 {object} map[string]interface{} "Account temporarily locked; retry_after gives the seconds to wait"
+// @Failure 429 {object} map[string]interface{} "Too many attempts; retry_after gives the seconds to wait"
+// @Failure 500 {object} map[string]string
+// @Header 423,429 {integer} Retry-After "Seconds until a new attempt is accepted"
+// @Router /auth/password-reset/confirm [post]
+func (h *AuthHandler) ConfirmPasswordReset(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.ConfirmPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid password reset confirmation", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.passwordResetService.ConfirmReset(ctx, &req, c.ClientIP()); err != nil {
+		log.Error(ctx, "Password reset confirmation failed", zap.Error(err))
+
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			respondThrottled(c, throttled)
+			return
+		}
+
+		if err.Error() == "invalid or expired reset code" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to reset password",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondThrottled answers a login refused by the login throttle: 423 if the account is locked,
+// 429 otherwise, with the seconds to wait in the Retry-After header and the body
+func respondThrottled(c *gin.Context, throttled *services.LoginThrottledError) {
+	status := http.StatusTooManyRequests
+	if throttled.Locked {
+		status = http.StatusLocked
+	}
+	retryAfter := int64(math.Ceil(throttled.RetryAfter.Seconds()))
+	c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+	c.JSON(status, gin.H{
+		"error":       throttled.Error(),
+		"retry_after": retryAfter,
+	})
+}
@@ -0,0 +1,485 @@
+package handlers
+
+import (
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateServiceOffering godoc
+// @Summary Adds a service to a business catalog
+// @Description Creates a new service offering for a business the authenticated user manages
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param service body models.CreateServiceOfferingRequest true "Service data"
+// @Success 201 {object} models.ServiceOfferingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/services [post]
+func (h *BusinessHandler) CreateServiceOffering(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CreateServiceOfferingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	offering, err := h.businessService.CreateServiceOffering(ctx, businessID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to create service offering", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to create service")
+		return
+	}
+
+	log.Info(ctx, "Service offering created successfully via HTTP",
+		zap.String("service_id", offering.ID.String()),
+		zap.String("business_id", businessID.String()),
+	)
+
+	c.JSON(http.StatusCreated, offering)
+}
+
+// GetServiceOfferings godoc
+// @Summary Lists the service catalog of a business
+// @Description Returns all services offered by a business
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Success 200 {array} models.ServiceOfferingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/services [get]
+func (h *BusinessHandler) GetServiceOfferings(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	offerings, err := h.businessService.GetServiceOfferings(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to get service offerings", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get services")
+		return
+	}
+
+	c.JSON(http.StatusOK, offerings)
+}
+
+// GetServiceOfferingByID godoc
+// @Summary Retrieves a service of a business catalog
+// @Description Returns a single service offered by a business
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param serviceId path string true "Service ID (UUID)"
+// @Success 200 {object} models.ServiceOfferingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/services/{serviceId} [get]
+func (h *BusinessHandler) GetServiceOfferingByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	serviceID, ok := parseUUIDParam(c, "serviceId")
+	if !ok {
+		return
+	}
+
+	offering, err := h.businessService.GetServiceOfferingByID(ctx, businessID, serviceID)
+	if err != nil {
+		log.Error(ctx, "Failed to get service offering", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get service")
+		return
+	}
+
+	c.JSON(http.StatusOK, offering)
+}
+
+// UpdateServiceOffering godoc
+// @Summary Updates a service of a business catalog
+// @Description Updates a service offering of a business the authenticated user manages
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param serviceId path string true "Service ID (UUID)"
+// @Param service body models.UpdateServiceOfferingRequest true "Service data"
+// @Success 200 {object} models.ServiceOfferingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/services/{serviceId} [put]
+func (h *BusinessHandler) UpdateServiceOffering(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	serviceID, ok := parseUUIDParam(c, "serviceId")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateServiceOfferingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	offering, err := h.businessService.UpdateServiceOffering(ctx, businessID, serviceID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to update service offering", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to update service")
+		return
+	}
+
+	c.JSON(http.StatusOK, offering)
+}
+
+// DeleteServiceOffering godoc
+// @Summary Removes a service from a business catalog
+// @Description Deletes a service offering of a business the authenticated user manages. Queues linked to it are kept without a service.
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param serviceId path string true "Service ID (UUID)"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/services/{serviceId} [delete]
+func (h *BusinessHandler) DeleteServiceOffering(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	serviceID, ok := parseUUIDParam(c, "serviceId")
+	if !ok {
+		return
+	}
+
+	if err := h.businessService.DeleteServiceOffering(ctx, businessID, serviceID); err != nil {
+		log.Error(ctx, "Failed to delete service offering", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to delete service")
+		return
+	}
+
+	log.Info(ctx, "Service offering deleted successfully via HTTP", zap.String("service_id", serviceID.String()))
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetOpeningHours godoc
+// @Summary Retrieves the opening hours of a business
+// @Description Returns the timezone and weekly opening hours of a business
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Success 200 {object} models.OpeningHoursResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/opening-hours [get]
+func (h *BusinessHandler) GetOpeningHours(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	hours, err := h.businessService.GetOpeningHours(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to get opening hours", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get opening hours")
+		return
+	}
+
+	c.JSON(http.StatusOK, hours)
+}
+
+// SetOpeningHours godoc
+// @Summary Replaces the opening hours of a business
+// @Description Sets the timezone and the whole weekly schedule of a business the authenticated user manages. Weekdays go from 0 (Sunday) to 6 (Saturday) and times use HH:MM.
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param hours body models.SetOpeningHoursRequest true "Weekly schedule"
+// @Success 200 {object} models.OpeningHoursResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/opening-hours [put]
+func (h *BusinessHandler) SetOpeningHours(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.SetOpeningHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	hours, err := h.businessService.SetOpeningHours(ctx, businessID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to set opening hours", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to set opening hours")
+		return
+	}
+
+	c.JSON(http.StatusOK, hours)
+}
+
+// CreateClosure godoc
+// @Summary Adds a closure to a business
+// @Description Registers a holiday or exceptional closure for a business the authenticated user manages. Customers cannot join queues or book appointments during a closure.
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param closure body models.CreateBusinessClosureRequest true "Closure period"
+// @Success 201 {object} models.BusinessClosure
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/closures [post]
+func (h *BusinessHandler) CreateClosure(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CreateBusinessClosureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	closure, err := h.businessService.CreateClosure(ctx, businessID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to create business closure", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to create closure")
+		return
+	}
+
+	c.JSON(http.StatusCreated, closure)
+}
+
+// GetClosures godoc
+// @Summary Lists the upcoming closures of a business
+// @Description Returns the current and future holidays and exceptional closures of a business
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Success 200 {array} models.BusinessClosure
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/closures [get]
+func (h *BusinessHandler) GetClosures(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	closures, err := h.businessService.GetUpcomingClosures(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to get business closures", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get closures")
+		return
+	}
+
+	c.JSON(http.StatusOK, closures)
+}
+
+// DeleteClosure godoc
+// @Summary Removes a closure from a business
+// @Description Deletes a closure of a business the authenticated user manages
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param closureId path string true "Closure ID (UUID)"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/closures/{closureId} [delete]
+func (h *BusinessHandler) DeleteClosure(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	closureID, ok := parseUUIDParam(c, "closureId")
+	if !ok {
+		return
+	}
+
+	if err := h.businessService.DeleteClosure(ctx, businessID, closureID); err != nil {
+		log.Error(ctx, "Failed to delete business closure", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to delete closure")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCancellationPolicy godoc
+// @Summary Retrieves the cancellation policy of a business
+// @Description Returns how long before an appointment customers may cancel or reschedule it for free
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Success 200 {object} models.CancellationPolicyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/cancellation-policy [get]
+func (h *BusinessHandler) GetCancellationPolicy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	policy, err := h.businessService.GetCancellationPolicy(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to get cancellation policy", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get cancellation policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// SetCancellationPolicy godoc
+// @Summary Configures the cancellation policy of a business
+// @Description Sets how many minutes before an appointment customers of a business the authenticated user manages may cancel or reschedule it for free. Later cancellations count against the customer's reputation.
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param policy body models.SetCancellationPolicyRequest true "Cancellation policy"
+// @Success 200 {object} models.CancellationPolicyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/cancellation-policy [put]
+func (h *BusinessHandler) SetCancellationPolicy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.SetCancellationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	policy, err := h.businessService.SetCancellationPolicy(ctx, businessID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to set cancellation policy", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to set cancellation policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
@@ -204,7 +204,7 @@ func (h *BusinessHandler) ListAllBusinesses(c *gin.Context) {
 
 // UpdateBusiness godoc
 // @Summary Updates a business
-// @Description Updates an existing business (owner only)
+// @Description Updates an existing business (requires the business:update permission)
 // @Tags businesses
 // @Accept json
 // @Produce json
@@ -221,12 +221,6 @@ func (h *BusinessHandler) ListAllBusinesses(c *gin.Context) {
 func (h *BusinessHandler) UpdateBusiness(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	// Get JWT claims from context
-	jwtClaims, ok := GetClaimsFromContext(c)
-	if !ok {
-		return
-	}
-
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
@@ -248,7 +242,7 @@ func (h *BusinessHandler) UpdateBusiness(c *gin.Context) {
 		return
 	}
 
-	business, err := h.businessService.UpdateBusiness(ctx, id, jwtClaims.UserID, &req)
+	business, err := h.businessService.UpdateBusiness(ctx, id, &req)
 	if err != nil {
 		log.Error(ctx, "Failed to update business", zap.Error(err))
 
@@ -260,14 +254,6 @@ func (h *BusinessHandler) UpdateBusiness(c *gin.Context) {
 			return
 		}
 
-		if err.Error() == "you are not authorized to update this business" {
-			c.JSON(http.StatusForbidden, ErrorResponse{
-				Error:   "forbidden",
-				Message: err.Error(),
-			})
-			return
-		}
-
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to update business",
@@ -284,7 +270,7 @@ func (h *BusinessHandler) UpdateBusiness(c *gin.Context) {
 
 // DeleteBusiness godoc
 // @Summary Deletes a business
-// @Description Deletes an existing business (owner only)
+// @Description Deletes an existing business (requires the business:delete permission)
 // @Tags businesses
 // @Accept json
 // @Produce json
@@ -300,12 +286,6 @@ func (h *BusinessHandler) UpdateBusiness(c *gin.Context) {
 func (h *BusinessHandler) DeleteBusiness(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	// Get JWT claims from context
-	jwtClaims, ok := GetClaimsFromContext(c)
-	if !ok {
-		return
-	}
-
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
@@ -317,7 +297,7 @@ func (h *BusinessHandler) DeleteBusiness(c *gin.Context) {
 		return
 	}
 
-	err = h.businessService.DeleteBusiness(ctx, id, jwtClaims.UserID)
+	err = h.businessService.DeleteBusiness(ctx, id)
 	if err != nil {
 		log.Error(ctx, "Failed to delete business", zap.Error(err))
 
@@ -329,14 +309,6 @@ func (h *BusinessHandler) DeleteBusiness(c *gin.Context) {
 			return
 		}
 
-		if err.Error() == "you are not authorized to delete this business" {
-			c.JSON(http.StatusForbidden, ErrorResponse{
-				Error:   "forbidden",
-				Message: err.Error(),
-			})
-			return
-		}
-
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to delete business",
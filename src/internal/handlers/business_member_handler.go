@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetMembers godoc
+// @Summary Lists the members of a business
+// @Description Returns the managers and staff of a business
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Success 200 {array} models.BusinessMember
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/members [get]
+func (h *BusinessHandler) GetMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	members, err := h.businessService.GetMembers(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to list business members", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to list members")
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// SetMember godoc
+// @Summary Adds a member to a business or changes their role
+// @Description Grants a user the manager or staff role on a business
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param userId path string true "User ID (UUID)"
+// @Param member body models.SetBusinessMemberRequest true "Member role"
+// @Success 200 {object} models.BusinessMember
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/members/{userId} [put]
+func (h *BusinessHandler) SetMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userID, ok := parseUUIDParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	var req models.SetBusinessMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	member, err := h.businessService.SetMember(ctx, businessID, userID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to set business member", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to set member")
+		return
+	}
+
+	log.Info(ctx, "Business member set via HTTP",
+		zap.String("business_id", businessID.String()),
+		zap.String("user_id", userID.String()),
+	)
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveMember godoc
+// @Summary Removes a member from a business
+// @Description Revokes the business role of a user
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param userId path string true "User ID (UUID)"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/members/{userId} [delete]
+func (h *BusinessHandler) RemoveMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userID, ok := parseUUIDParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.businessService.RemoveMember(ctx, businessID, userID); err != nil {
+		log.Error(ctx, "Failed to remove business member", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to remove member")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
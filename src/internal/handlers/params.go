@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"easy-queue-go/src/internal/log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// serviceError describes how a known service error is exposed over HTTP
+type serviceError struct {
+	status int
+	code   string
+}
+
+// serviceErrors maps well-known service error messages to HTTP responses
+var serviceErrors = map[string]serviceError{
+	"business not found":                                  {http.StatusNotFound, "business_not_found"},
+	"queue not found":                                     {http.StatusNotFound, "queue_not_found"},
+	"ticket not found":                                    {http.StatusNotFound, "ticket_not_found"},
+	"insufficient permissions":                            {http.StatusForbidden, "forbidden"},
+	"invalid ticket status transition":                    {http.StatusConflict, "invalid_transition"},
+	"ticket status changed concurrently":                  {http.StatusConflict, "concurrent_update"},
+	"user not found":                                      {http.StatusNotFound, "user_not_found"},
+	"user must have Customer role to join a queue":        {http.StatusForbidden, "forbidden"},
+	"queue is not accepting customers":                    {http.StatusConflict, "queue_closed"},
+	"customer already has an active ticket in this queue": {http.StatusConflict, "already_in_queue"},
+	"no tickets waiting in this queue":                    {http.StatusNotFound, "queue_empty"},
+	"service offering not found":                          {http.StatusNotFound, "service_not_found"},
+	"ticket is no longer active":                          {http.StatusConflict, "ticket_inactive"},
+	"business location is not configured":                 {http.StatusConflict, "location_not_configured"},
+	"invalid timezone":                                    {http.StatusBadRequest, "invalid_timezone"},
+	"invalid opening hours":                               {http.StatusBadRequest, "invalid_opening_hours"},
+	"invalid date":                                        {http.StatusBadRequest, "invalid_date"},
+	"appointment must be in the future":                   {http.StatusBadRequest, "invalid_appointment"},
+	"appointment is outside opening hours":                {http.StatusConflict, "outside_opening_hours"},
+	"appointment slot is already booked":                  {http.StatusConflict, "slot_taken"},
+	"business closure not found":                          {http.StatusNotFound, "closure_not_found"},
+	"invalid closure period":                              {http.StatusBadRequest, "invalid_closure"},
+	"business is closed":                                  {http.StatusConflict, "business_closed"},
+	"ticket can no longer be cancelled":                   {http.StatusConflict, "ticket_not_cancellable"},
+	"only waiting tickets can be rescheduled":             {http.StatusConflict, "ticket_not_reschedulable"},
+	"too late to reschedule this ticket":                  {http.StatusConflict, "reschedule_deadline_passed"},
+	"verified phone number required":                      {http.StatusForbidden, "phone_not_verified"},
+	"invalid or expired verification code":                {http.StatusBadRequest, "invalid_code"},
+	"phone number is already verified":                    {http.StatusConflict, "phone_already_verified"},
+	"phone verification is not available":                 {http.StatusServiceUnavailable, "phone_verification_unavailable"},
+	"phone number changed during verification":            {http.StatusConflict, "phone_changed"},
+	"cannot remove the last admin":                        {http.StatusConflict, "last_admin"},
+	"user must keep at least one role":                    {http.StatusConflict, "last_role"},
+	"business member not found":                           {http.StatusNotFound, "member_not_found"},
+	"business owner cannot be a member":                   {http.StatusConflict, "owner_not_member"},
+	"user account is inactive":                            {http.StatusConflict, "user_inactive"},
+	"whatsapp message not found":                          {http.StatusNotFound, "message_not_found"},
+}
+
+// parseUUIDParam parses a UUID path parameter
+// Returns the UUID and true if successful, or responds with error and returns uuid.Nil, false
+func parseUUIDParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	ctx := c.Request.Context()
+
+	value := c.Param(name)
+	id, err := uuid.Parse(value)
+	if err != nil {
+		log.Warn(ctx, "Invalid UUID path parameter", zap.String(name, value))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid UUID format",
+		})
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// respondWithServiceError maps a service error to its HTTP response,
+// falling back to 500 with the given message for unexpected errors
+func respondWithServiceError(c *gin.Context, err error, fallbackMessage string) {
+	if mapped, ok := serviceErrors[err.Error()]; ok {
+		c.JSON(mapped.status, ErrorResponse{
+			Error:   mapped.code,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "internal_error",
+		Message: fallbackMessage,
+	})
+}
@@ -0,0 +1,455 @@
+package handlers
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/services"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// QueueHandler manages HTTP requests related to the queues of a business
+type QueueHandler struct {
+	queueService services.QueueService
+}
+
+// NewQueueHandler creates a new instance of QueueHandler
+func NewQueueHandler(queueService services.QueueService) *QueueHandler {
+	return &QueueHandler{
+		queueService: queueService,
+	}
+}
+
+// CreateQueue godoc
+// @Summary Creates a new queue
+// @Description Creates a new queue for a business the authenticated user manages
+// @Tags queues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queue body models.CreateQueueRequest true "Queue data"
+// @Success 201 {object} models.QueueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues [post]
+func (h *QueueHandler) CreateQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CreateQueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	queue, err := h.queueService.CreateQueue(ctx, businessID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to create queue", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to create queue")
+		return
+	}
+
+	log.Info(ctx, "Queue created successfully via HTTP",
+		zap.String("queue_id", queue.ID.String()),
+		zap.String("business_id", businessID.String()),
+	)
+
+	c.JSON(http.StatusCreated, queue)
+}
+
+// GetQueues godoc
+// @Summary Lists the queues of a business
+// @Description Returns all queues of a business the authenticated user works at, or of any business for customers
+// @Tags queues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Success 200 {array} models.QueueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues [get]
+func (h *QueueHandler) GetQueues(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	queues, err := h.queueService.GetQueuesByBusiness(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to get queues", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get queues")
+		return
+	}
+
+	c.JSON(http.StatusOK, queues)
+}
+
+// GetQueueByID godoc
+// @Summary Retrieves a queue by ID
+// @Description Returns a queue of a business the authenticated user works at, or of any business for customers
+// @Tags queues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queueId path string true "Queue ID (UUID)"
+// @Success 200 {object} models.QueueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues/{queueId} [get]
+func (h *QueueHandler) GetQueueByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	queueID, ok := parseUUIDParam(c, "queueId")
+	if !ok {
+		return
+	}
+
+	queue, err := h.queueService.GetQueueByID(ctx, businessID, queueID)
+	if err != nil {
+		log.Error(ctx, "Failed to get queue", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get queue")
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// UpdateQueue godoc
+// @Summary Updates a queue
+// @Description Updates a queue of a business the authenticated user manages
+// @Tags queues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queueId path string true "Queue ID (UUID)"
+// @Param queue body models.UpdateQueueRequest true "Queue data to update"
+// @Success 200 {object} models.QueueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues/{queueId} [put]
+func (h *QueueHandler) UpdateQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	queueID, ok := parseUUIDParam(c, "queueId")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateQueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	queue, err := h.queueService.UpdateQueue(ctx, businessID, queueID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to update queue", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to update queue")
+		return
+	}
+
+	log.Info(ctx, "Queue updated successfully via HTTP", zap.String("queue_id", queue.ID.String()))
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// DeleteQueue godoc
+// @Summary Deletes a queue
+// @Description Deletes a queue and its tickets from a business the authenticated user manages
+// @Tags queues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queueId path string true "Queue ID (UUID)"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues/{queueId} [delete]
+func (h *QueueHandler) DeleteQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	queueID, ok := parseUUIDParam(c, "queueId")
+	if !ok {
+		return
+	}
+
+	if err := h.queueService.DeleteQueue(ctx, businessID, queueID); err != nil {
+		log.Error(ctx, "Failed to delete queue", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to delete queue")
+		return
+	}
+
+	log.Info(ctx, "Queue deleted successfully via HTTP", zap.String("queue_id", queueID.String()))
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListQueueTickets godoc
+// @Summary Lists the tickets of a queue
+// @Description Returns the tickets of a queue in queue order, optionally filtered by a comma-separated list of statuses, including each customer's latest check-in result
+// @Tags queues
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queueId path string true "Queue ID (UUID)"
+// @Param status query string false "Comma-separated statuses (waiting,called,in_service,done,cancelled,no_show)"
+// @Success 200 {array} models.TicketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues/{queueId}/tickets [get]
+func (h *QueueHandler) ListQueueTickets(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	queueID, ok := parseUUIDParam(c, "queueId")
+	if !ok {
+		return
+	}
+
+	var statuses []models.TicketStatus
+	if statusParam := c.Query("status"); statusParam != "" {
+		for _, status := range strings.Split(statusParam, ",") {
+			statuses = append(statuses, models.TicketStatus(strings.TrimSpace(status)))
+		}
+	}
+
+	tickets, err := h.queueService.ListQueueTickets(ctx, businessID, queueID, statuses)
+	if err != nil {
+		log.Error(ctx, "Failed to list queue tickets", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to list tickets")
+		return
+	}
+
+	c.JSON(http.StatusOK, tickets)
+}
+
+// CallNextTicket godoc
+// @Summary Calls the next ticket
+// @Description Atomically calls the first waiting ticket of the queue; concurrent callers never receive the same ticket
+// @Tags queue-console
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queueId path string true "Queue ID (UUID)"
+// @Success 200 {object} models.TicketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues/{queueId}/call-next [post]
+func (h *QueueHandler) CallNextTicket(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	queueID, ok := parseUUIDParam(c, "queueId")
+	if !ok {
+		return
+	}
+
+	ticket, err := h.queueService.CallNextTicket(ctx, businessID, queueID)
+	if err != nil {
+		log.Warn(ctx, "Failed to call next ticket", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to call next ticket")
+		return
+	}
+
+	log.Info(ctx, "Next ticket called via HTTP",
+		zap.String("ticket_id", ticket.ID.String()),
+		zap.Int("number", ticket.Number),
+	)
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+// SkipTicket godoc
+// @Summary Skips a called ticket
+// @Description Marks a called ticket as skipped; it can be recalled later
+// @Tags queue-console
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queueId path string true "Queue ID (UUID)"
+// @Param ticketId path string true "Ticket ID (UUID)"
+// @Success 200 {object} models.TicketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues/{queueId}/tickets/{ticketId}/skip [post]
+func (h *QueueHandler) SkipTicket(c *gin.Context) {
+	h.handleTicketAction(c, h.queueService.SkipTicket, "Failed to skip ticket")
+}
+
+// RecallTicket godoc
+// @Summary Recalls a skipped ticket
+// @Description Calls a previously skipped ticket again
+// @Tags queue-console
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queueId path string true "Queue ID (UUID)"
+// @Param ticketId path string true "Ticket ID (UUID)"
+// @Success 200 {object} models.TicketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues/{queueId}/tickets/{ticketId}/recall [post]
+func (h *QueueHandler) RecallTicket(c *gin.Context) {
+	h.handleTicketAction(c, h.queueService.RecallTicket, "Failed to recall ticket")
+}
+
+// StartTicket godoc
+// @Summary Marks a ticket as in service
+// @Description Marks a called ticket as being served
+// @Tags queue-console
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queueId path string true "Queue ID (UUID)"
+// @Param ticketId path string true "Ticket ID (UUID)"
+// @Success 200 {object} models.TicketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues/{queueId}/tickets/{ticketId}/start [post]
+func (h *QueueHandler) StartTicket(c *gin.Context) {
+	h.handleTicketAction(c, h.queueService.StartTicket, "Failed to start ticket")
+}
+
+// CompleteTicket godoc
+// @Summary Completes a ticket
+// @Description Marks an in-service ticket as done
+// @Tags queue-console
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param queueId path string true "Queue ID (UUID)"
+// @Param ticketId path string true "Ticket ID (UUID)"
+// @Success 200 {object} models.TicketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /businesses/{id}/queues/{queueId}/tickets/{ticketId}/complete [post]
+func (h *QueueHandler) CompleteTicket(c *gin.Context) {
+	h.handleTicketAction(c, h.queueService.CompleteTicket, "Failed to complete ticket")
+}
+
+// ticketAction is a queue console operation applied to a single ticket
+type ticketAction func(ctx context.Context, businessID, queueID, ticketID uuid.UUID) (*models.TicketResponse, error)
+
+// handleTicketAction parses the console path parameters and applies the action to the ticket
+func (h *QueueHandler) handleTicketAction(c *gin.Context, action ticketAction, failureMessage string) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	queueID, ok := parseUUIDParam(c, "queueId")
+	if !ok {
+		return
+	}
+
+	ticketID, ok := parseUUIDParam(c, "ticketId")
+	if !ok {
+		return
+	}
+
+	ticket, err := action(ctx, businessID, queueID, ticketID)
+	if err != nil {
+		log.Warn(ctx, failureMessage, zap.Error(err), zap.String("ticket_id", ticketID.String()))
+		respondWithServiceError(c, err, failureMessage)
+		return
+	}
+
+	log.Info(ctx, "Ticket updated via HTTP",
+		zap.String("ticket_id", ticket.ID.String()),
+		zap.String("status", string(ticket.Status)),
+	)
+
+	c.JSON(http.StatusOK, ticket)
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TicketHandler manages HTTP requests made by customers about their tickets
+type TicketHandler struct {
+	ticketService services.TicketService
+}
+
+// NewTicketHandler creates a new instance of TicketHandler
+func NewTicketHandler(ticketService services.TicketService) *TicketHandler {
+	return &TicketHandler{
+		ticketService: ticketService,
+	}
+}
+
+// JoinQueue godoc
+// @Summary Joins a queue
+// @Description Issues a ticket for the authenticated customer and returns its position and estimated start time
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param queueId path string true "Queue ID (UUID)"
+// @Success 201 {object} models.TicketPositionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /queues/{queueId}/tickets [post]
+func (h *TicketHandler) JoinQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	queueID, ok := parseUUIDParam(c, "queueId")
+	if !ok {
+		return
+	}
+
+	position, err := h.ticketService.JoinQueue(ctx, jwtClaims.UserID, queueID)
+	if err != nil {
+		log.Error(ctx, "Failed to join queue", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to join queue")
+		return
+	}
+
+	log.Info(ctx, "Customer joined queue via HTTP",
+		zap.String("ticket_id", position.Ticket.ID.String()),
+		zap.Int("position", position.Position),
+	)
+
+	c.JSON(http.StatusCreated, position)
+}
+
+// GetMyTickets godoc
+// @Summary Lists the authenticated customer's active tickets
+// @Description Returns the tickets of the current customer that are still waiting, called or in service
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.TicketResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tickets/my [get]
+func (h *TicketHandler) GetMyTickets(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	tickets, err := h.ticketService.GetMyTickets(ctx, jwtClaims.UserID)
+	if err != nil {
+		log.Error(ctx, "Failed to get tickets", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get tickets")
+		return
+	}
+
+	c.JSON(http.StatusOK, tickets)
+}
+
+// GetTicketPosition godoc
+// @Summary Retrieves a ticket's position in line
+// @Description Returns the current position and estimated start time of one of the authenticated customer's tickets
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ticketId path string true "Ticket ID (UUID)"
+// @Success 200 {object} models.TicketPositionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tickets/{ticketId}/position [get]
+func (h *TicketHandler) GetTicketPosition(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	ticketID, ok := parseUUIDParam(c, "ticketId")
+	if !ok {
+		return
+	}
+
+	position, err := h.ticketService.GetTicketPosition(ctx, jwtClaims.UserID, ticketID)
+	if err != nil {
+		log.Error(ctx, "Failed to get ticket position", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to get ticket position")
+		return
+	}
+
+	c.JSON(http.StatusOK, position)
+}
+
+// CheckIn godoc
+// @Summary Checks in to a ticket with the device location
+// @Description Validates the distance between the customer's device and the business against the service check-in radius and records the result on the ticket
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ticketId path string true "Ticket ID (UUID)"
+// @Param location body models.CheckInRequest true "Device coordinates"
+// @Success 200 {object} models.CheckInResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tickets/{ticketId}/check-in [post]
+func (h *TicketHandler) CheckIn(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	ticketID, ok := parseUUIDParam(c, "ticketId")
+	if !ok {
+		return
+	}
+
+	var req models.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.ticketService.CheckIn(ctx, jwtClaims.UserID, ticketID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to check in", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to check in")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CancelTicket godoc
+// @Summary Cancels a ticket
+// @Description Cancels a ticket or appointment of the authenticated customer. Cancelling inside the business cancellation window, or after being called, counts against the customer's reputation.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ticketId path string true "Ticket ID (UUID)"
+// @Success 200 {object} models.TicketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tickets/{ticketId}/cancel [post]
+func (h *TicketHandler) CancelTicket(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	ticketID, ok := parseUUIDParam(c, "ticketId")
+	if !ok {
+		return
+	}
+
+	ticket, err := h.ticketService.CancelTicket(ctx, jwtClaims.UserID, ticketID)
+	if err != nil {
+		log.Error(ctx, "Failed to cancel ticket", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to cancel ticket")
+		return
+	}
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+// RescheduleTicket godoc
+// @Summary Moves a ticket to another time slot
+// @Description Moves a waiting ticket of the authenticated customer to another time slot. Appointments can only be moved before the business cancellation window; walk-in tickets become appointments.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ticketId path string true "Ticket ID (UUID)"
+// @Param slot body models.RescheduleTicketRequest true "New slot start"
+// @Success 200 {object} models.TicketPositionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tickets/{ticketId}/reschedule [post]
+func (h *TicketHandler) RescheduleTicket(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	ticketID, ok := parseUUIDParam(c, "ticketId")
+	if !ok {
+		return
+	}
+
+	var req models.RescheduleTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	position, err := h.ticketService.RescheduleTicket(ctx, jwtClaims.UserID, ticketID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to reschedule ticket", zap.Error(err))
+		respondWithServiceError(c, err, "Failed to reschedule ticket")
+		return
+	}
+
+	c.JSON(http.StatusOK, position)
+}
@@ -7,18 +7,21 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 // UserHandler manages HTTP requests related to users
 type UserHandler struct {
-	userService services.UserService
+	userService              services.UserService
+	phoneVerificationService services.PhoneVerificationService
 }
 
 // NewUserHandler creates a new instance of UserHandler
-func NewUserHandler(userService services.UserService) *UserHandler {
+func NewUserHandler(userService services.UserService, phoneVerificationService services.PhoneVerificationService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:              userService,
+		phoneVerificationService: phoneVerificationService,
 	}
 }
 
@@ -30,13 +33,14 @@ type ErrorResponse struct {
 
 // CreateUser godoc
 // @Summary Creates a new user
-// @Description Creates a new user in the system with email, password, phone and role
+// @Description Registers a new user with email, password, phone and roles. Only the BO and CU roles can be chosen; admins are created by other admins.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param user body models.CreateUserRequest true "User data"
 // @Success 201 {object} models.UserResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /users [post]
@@ -66,6 +70,14 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "admin role cannot be self-assigned" {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "forbidden_role",
+				Message: err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to create user",
@@ -81,6 +93,56 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	c.JSON(http.StatusCreated, user)
 }
 
+// CreateUserAsAdmin godoc
+// @Summary Creates a user (Admin only)
+// @Description Creates a user with any roles, including the admin role that cannot be chosen at registration
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user body models.AdminCreateUserRequest true "User data"
+// @Success 201 {object} models.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users [post]
+func (h *UserHandler) CreateUserAsAdmin(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.AdminCreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.CreateUserAsAdmin(ctx, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to create user", zap.Error(err))
+
+		if err.Error() == "user with email "+req.Email+" already exists" {
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "user_already_exists",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to create user",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
 // GetMyProfile godoc
 // @Summary Retrieves the authenticated user's profile
 // @Description Returns the profile data of the currently authenticated user
@@ -124,6 +186,119 @@ func (h *UserHandler) GetMyProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// UpdateMyProfile godoc
+// @Summary Updates the authenticated user's profile
+// @Description Updates the phone number of the authenticated user. A changed phone number is no longer verified and a new verification code is sent to it over WhatsApp.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.UpdateProfileRequest true "Profile fields to change"
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/me [patch]
+func (h *UserHandler) UpdateMyProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(ctx, jwtClaims.UserID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to update user profile", zap.Error(err), zap.String("user_id", jwtClaims.UserID.String()))
+		respondWithServiceError(c, err, "Failed to update user profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// SendPhoneVerification godoc
+// @Summary Sends a phone verification code
+// @Description Sends a new verification code over WhatsApp to the phone number of the authenticated user
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 202 "Accepted"
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/me/phone/verification [post]
+func (h *UserHandler) SendPhoneVerification(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.phoneVerificationService.SendCode(ctx, jwtClaims.UserID); err != nil {
+		log.Error(ctx, "Failed to send phone verification code", zap.Error(err), zap.String("user_id", jwtClaims.UserID.String()))
+		respondWithServiceError(c, err, "Failed to send phone verification code")
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// VerifyPhone godoc
+// @Summary Verifies the phone number
+// @Description Confirms the phone number of the authenticated user with the code sent over WhatsApp
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.VerifyPhoneRequest true "Verification code"
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/me/phone/verify [post]
+func (h *UserHandler) VerifyPhone(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jwtClaims, ok := GetClaimsFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.VerifyPhoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn(ctx, "Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.phoneVerificationService.VerifyPhone(ctx, jwtClaims.UserID, &req)
+	if err != nil {
+		log.Error(ctx, "Failed to verify phone", zap.Error(err), zap.String("user_id", jwtClaims.UserID.String()))
+		respondWithServiceError(c, err, "Failed to verify phone")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
 // ListAllUsers godoc
 // @Summary Lists all users (Admin only)
 // @Description Returns a list of all users in the system
@@ -154,3 +329,177 @@ func (h *UserHandler) ListAllUsers(c *gin.Context) {
 	log.Info(ctx, "Successfully listed all users", zap.Int("count", len(users)))
 	c.JSON(http.StatusOK, users)
 }
+
+// DeactivateUser godoc
+// @Summary Deactivates a user (Admin only)
+// @Description Deactivates a user account and ends all of its sessions. The last active admin cannot be deactivated.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/deactivate [post]
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	h.setUserActive(c, false)
+}
+
+// ReactivateUser godoc
+// @Summary Reactivates a user (Admin only)
+// @Description Reactivates a deactivated user account. Sessions ended by the deactivation stay ended.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/reactivate [post]
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	h.setUserActive(c, true)
+}
+
+// setUserActive handles deactivation and reactivation of the user in the id path parameter
+func (h *UserHandler) setUserActive(c *gin.Context, active bool) {
+	ctx := c.Request.Context()
+
+	userID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.SetUserActive(ctx, userID, active)
+	if err != nil {
+		log.Error(ctx, "Failed to change user status", zap.Error(err), zap.String("user_id", userID.String()))
+		respondWithServiceError(c, err, "Failed to change user status")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// AddUserRole godoc
+// @Summary Grants a role to a user (Admin only)
+// @Description Grants a role to a user. The change applies to the user's existing tokens immediately.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param role path string true "Role" Enums(BO, CU, AD)
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/roles/{role} [put]
+func (h *UserHandler) AddUserRole(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, role, ok := parseUserRoleParams(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.AddUserRole(ctx, userID, role)
+	if err != nil {
+		log.Error(ctx, "Failed to add user role", zap.Error(err), zap.String("user_id", userID.String()))
+		respondWithServiceError(c, err, "Failed to add user role")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// RemoveUserRole godoc
+// @Summary Removes a role from a user (Admin only)
+// @Description Removes a role from a user, who must keep at least one role. The admin role cannot be removed from the last active admin. The change applies to the user's existing tokens immediately.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param role path string true "Role" Enums(BO, CU, AD)
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/roles/{role} [delete]
+func (h *UserHandler) RemoveUserRole(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, role, ok := parseUserRoleParams(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.RemoveUserRole(ctx, userID, role)
+	if err != nil {
+		log.Error(ctx, "Failed to remove user role", zap.Error(err), zap.String("user_id", userID.String()))
+		respondWithServiceError(c, err, "Failed to remove user role")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser godoc
+// @Summary Deletes a user (Admin only)
+// @Description Deletes a user together with their businesses, tickets and sessions. The last active admin cannot be deleted.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id} [delete]
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.userService.DeleteUser(ctx, userID); err != nil {
+		log.Error(ctx, "Failed to delete user", zap.Error(err), zap.String("user_id", userID.String()))
+		respondWithServiceError(c, err, "Failed to delete user")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseUserRoleParams extracts the id and role path parameters, writing a 400 response if either is invalid
+func parseUserRoleParams(c *gin.Context) (uuid.UUID, models.UserRole, bool) {
+	userID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return uuid.Nil, "", false
+	}
+
+	role := models.UserRole(c.Param("role"))
+	if !role.IsValid() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_role",
+			Message: "Role must be one of BO, CU, AD",
+		})
+		return uuid.Nil, "", false
+	}
+
+	return userID, role, true
+}
@@ -4,9 +4,12 @@ import (
 	"easy-queue-go/src/internal/log"
 	"easy-queue-go/src/internal/models"
 	"easy-queue-go/src/internal/services"
+	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -236,20 +239,52 @@ func (h *WhatsAppHandler) VerifyWebhook(c *gin.Context) {
 
 // ReceiveWebhook godoc
 // @Summary Receive WhatsApp webhook events
-// @Description Receives webhook events from WhatsApp (called by Meta when messages are received)
+// @Description Receives webhook events from WhatsApp (called by Meta when messages are received). Events must be signed with the app secret in the X-Hub-Signature-256 header.
 // @Tags whatsapp
 // @Accept json
 // @Produce json
+// @Param X-Hub-Signature-256 header string true "HMAC-SHA256 of the body keyed with the app secret, as sha256=<hex>"
 // @Param payload body models.WhatsAppWebhookPayload true "Webhook payload"
 // @Success 200 {object} object{status=string}
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /whatsapp/webhook [post]
 func (h *WhatsAppHandler) ReceiveWebhook(c *gin.Context) {
 	ctx := c.Request.Context()
 
+	if h.whatsappService == nil {
+		log.Warn(ctx, "Webhook received but WhatsApp is not configured")
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "whatsapp_unavailable",
+			Message: "WhatsApp integration is not configured",
+		})
+		return
+	}
+
+	// The signature covers the exact bytes Meta sent, so the body is read raw before decoding it
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		log.Warn(ctx, "Failed to read webhook body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_payload",
+			Message: "Failed to read request body",
+		})
+		return
+	}
+
+	if err := h.whatsappService.VerifyWebhookSignature(body, c.GetHeader("X-Hub-Signature-256")); err != nil {
+		log.Warn(ctx, "Rejected webhook with invalid signature", zap.Error(err))
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "invalid_signature",
+			Message: "Webhook signature verification failed",
+		})
+		return
+	}
+
 	var payload models.WhatsAppWebhookPayload
-	if err := c.ShouldBindJSON(&payload); err != nil {
+	if err := json.Unmarshal(body, &payload); err != nil {
 		log.Warn(ctx, "Invalid webhook payload", zap.Error(err))
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_payload",
@@ -276,14 +311,104 @@ func (h *WhatsAppHandler) ReceiveWebhook(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
+// GetMessage godoc
+// @Summary Get the delivery status of a WhatsApp message (Debug)
+// @Description Returns the log entry of an outbound message with the latest delivery status reported by Meta (sent, delivered, read or failed)
+// @Tags whatsapp-debug
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param messageId path string true "Message ID returned when the message was sent"
+// @Success 200 {object} models.WhatsAppMessage
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /debug/whatsapp/messages/{messageId} [get]
+func (h *WhatsAppHandler) GetMessage(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if h.whatsappService == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "whatsapp_unavailable",
+			Message: "WhatsApp integration is not configured",
+		})
+		return
+	}
+
+	message, err := h.whatsappService.GetMessage(ctx, c.Param("messageId"))
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to get message")
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// ListBusinessMessages godoc
+// @Summary List the WhatsApp messages of a business
+// @Description Returns the latest WhatsApp messages sent to customers about tickets of the business, newest first, with the delivery status reported by Meta (sent, delivered, read or failed)
+// @Tags whatsapp
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Business ID (UUID)"
+// @Param ticket_id query string false "Only messages about this ticket (UUID)"
+// @Success 200 {array} models.WhatsAppMessage
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /businesses/{id}/whatsapp-messages [get]
+func (h *WhatsAppHandler) ListBusinessMessages(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	businessID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var ticketID *uuid.UUID
+	if ticketParam := c.Query("ticket_id"); ticketParam != "" {
+		id, err := uuid.Parse(ticketParam)
+		if err != nil {
+			log.Warn(ctx, "Invalid ticket_id query parameter", zap.String("ticket_id", ticketParam))
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_id",
+				Message: "Invalid UUID format",
+			})
+			return
+		}
+		ticketID = &id
+	}
+
+	if h.whatsappService == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "whatsapp_unavailable",
+			Message: "WhatsApp integration is not configured",
+		})
+		return
+	}
+
+	messages, err := h.whatsappService.ListBusinessMessages(ctx, businessID, ticketID)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to list messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
 // GetStatus godoc
 // @Summary Get WhatsApp integration status (Debug)
-// @Description Returns the status of the WhatsApp integration
+// @Description Returns the status of the WhatsApp integration and of its access token
 // @Tags whatsapp-debug
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} object{status=string,configured=bool}
+// @Success 200 {object} object{status=string,configured=bool,token=models.WhatsAppTokenStatus}
 // @Failure 401 {object} ErrorResponse
 // @Router /debug/whatsapp/status [get]
 func (h *WhatsAppHandler) GetStatus(c *gin.Context) {
@@ -291,10 +416,25 @@ func (h *WhatsAppHandler) GetStatus(c *gin.Context) {
 
 	log.Info(ctx, "Debug: Getting WhatsApp status")
 
-	// Simple status check
+	if h.whatsappService == nil {
+		c.JSON(http.StatusOK, gin.H{
+			"status":     "inactive",
+			"configured": false,
+			"message":    "WhatsApp integration is not configured",
+		})
+		return
+	}
+
+	token := h.whatsappService.GetTokenStatus()
+	message := "WhatsApp integration is configured and ready"
+	if !token.Valid {
+		message = "WhatsApp access token is invalid or expired"
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"status":     "active",
-		"configured": h.whatsappService != nil,
-		"message":    "WhatsApp integration is configured and ready",
+		"configured": true,
+		"message":    message,
+		"token":      token,
 	})
 }
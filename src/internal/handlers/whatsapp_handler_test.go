@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"easy-queue-go/src/internal/config"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/services"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const testAppSecret = "test-app-secret"
+
+// fakeMessageRepository keeps the WhatsApp message log in memory
+type fakeMessageRepository struct {
+	statuses  []*models.WhatsAppStatusUpdate
+	statusErr error // Returned by ApplyStatus when set
+}
+
+func (r *fakeMessageRepository) Create(ctx context.Context, message *models.WhatsAppMessage) error {
+	return nil
+}
+
+func (r *fakeMessageRepository) ApplyStatus(ctx context.Context, update *models.WhatsAppStatusUpdate) error {
+	if r.statusErr != nil {
+		return r.statusErr
+	}
+	r.statuses = append(r.statuses, update)
+	return nil
+}
+
+func (r *fakeMessageRepository) FindByID(ctx context.Context, id string) (*models.WhatsAppMessage, error) {
+	return nil, fmt.Errorf("whatsapp message not found")
+}
+
+func (r *fakeMessageRepository) Link(ctx context.Context, id string, businessID, ticketID *uuid.UUID) error {
+	return fmt.Errorf("whatsapp message not found")
+}
+
+func (r *fakeMessageRepository) FindByBusiness(ctx context.Context, businessID uuid.UUID, ticketID *uuid.UUID, limit int) ([]*models.WhatsAppMessage, error) {
+	return []*models.WhatsAppMessage{}, nil
+}
+
+// fakeCommandService records the inbound messages passed to it
+type fakeCommandService struct {
+	messages []*models.WhatsAppInboundMessage
+}
+
+func (c *fakeCommandService) HandleMessage(ctx context.Context, message *models.WhatsAppInboundMessage) error {
+	c.messages = append(c.messages, message)
+	return nil
+}
+
+// sign computes the X-Hub-Signature-256 header Meta would send for the body
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// loadPayload reads a recorded webhook payload from testdata
+func loadPayload(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read payload %s: %v", name, err)
+	}
+	return body
+}
+
+// postWebhook sends the body to ReceiveWebhook with the given signature header, if any
+func postWebhook(t *testing.T, appSecret string, body []byte, signature string) *httptest.ResponseRecorder {
+	t.Helper()
+	return postWebhookWith(t, appSecret, body, signature, &fakeMessageRepository{}, &fakeCommandService{})
+}
+
+// postWebhookWith is postWebhook with the message log the service records statuses in
+// and the command service inbound messages are passed to
+func postWebhookWith(t *testing.T, appSecret string, body []byte, signature string, messageRepo *fakeMessageRepository, commands *fakeCommandService) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.WhatsAppConfig{AppSecret: appSecret}
+	service := services.NewWhatsAppService(cfg, messageRepo, commands, services.NewWhatsAppTokenManager(cfg, nil))
+	router := gin.New()
+	router.POST("/whatsapp/webhook", NewWhatsAppHandler(service).ReceiveWebhook)
+
+	req := httptest.NewRequest(http.MethodPost, "/whatsapp/webhook", bytes.NewReader(body))
+	req = req.WithContext(log.Initialize(context.Background()))
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature-256", signature)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestReceiveWebhookAcceptsSignedPayloads(t *testing.T) {
+	for _, name := range []string{"whatsapp_webhook_text_message.json", "whatsapp_webhook_message_status.json"} {
+		t.Run(name, func(t *testing.T) {
+			body := loadPayload(t, name)
+
+			recorder := postWebhook(t, testAppSecret, body, sign(testAppSecret, body))
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestReceiveWebhookRejectsInvalidSignatures(t *testing.T) {
+	body := loadPayload(t, "whatsapp_webhook_text_message.json")
+	tampered := bytes.Replace(body, []byte("another color"), []byte("a refund"), 1)
+
+	tests := []struct {
+		name      string
+		appSecret string
+		body      []byte
+		signature string
+	}{
+		{"missing signature", testAppSecret, body, ""},
+		{"signed with another secret", testAppSecret, body, sign("another-secret", body)},
+		{"body changed after signing", testAppSecret, tampered, sign(testAppSecret, body)},
+		{"missing sha256 prefix", testAppSecret, body, sign(testAppSecret, body)[len("sha256="):]},
+		{"signature not hex encoded", testAppSecret, body, "sha256=not-hex"},
+		{"app secret not configured", "", body, sign("", body)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := postWebhook(t, tt.appSecret, tt.body, tt.signature)
+
+			if recorder.Code != http.StatusUnauthorized {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusUnauthorized, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestReceiveWebhookRejectsSignedInvalidJSON(t *testing.T) {
+	body := []byte(`{"object": "whatsapp_business_account", "entry": [`)
+
+	recorder := postWebhook(t, testAppSecret, body, sign(testAppSecret, body))
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestReceiveWebhookRecordsMessageStatus(t *testing.T) {
+	body := loadPayload(t, "whatsapp_webhook_message_status.json")
+	messageRepo := &fakeMessageRepository{}
+
+	recorder := postWebhookWith(t, testAppSecret, body, sign(testAppSecret, body), messageRepo, &fakeCommandService{})
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	if len(messageRepo.statuses) != 1 {
+		t.Fatalf("expected 1 recorded status, got %d", len(messageRepo.statuses))
+	}
+
+	update := messageRepo.statuses[0]
+	if update.MessageID != "wamid.HBgLMTY1MDM4Nzk0MzkVAgARGBI3MTE5MjNBQzdEQzJBRjVBRTAA" {
+		t.Errorf("unexpected message id %q", update.MessageID)
+	}
+	if update.Status != models.WhatsAppMessageStatusDelivered {
+		t.Errorf("expected status %q, got %q", models.WhatsAppMessageStatusDelivered, update.Status)
+	}
+	if update.Recipient != "16505551234" {
+		t.Errorf("unexpected recipient %q", update.Recipient)
+	}
+	if update.Timestamp.Unix() != 1750263773 {
+		t.Errorf("unexpected timestamp %v", update.Timestamp)
+	}
+}
+
+func TestReceiveWebhookAcknowledgesStatusesThatFailToStore(t *testing.T) {
+	body := loadPayload(t, "whatsapp_webhook_message_status.json")
+	messageRepo := &fakeMessageRepository{statusErr: fmt.Errorf("failed to apply whatsapp message status: connection refused")}
+
+	recorder := postWebhookWith(t, testAppSecret, body, sign(testAppSecret, body), messageRepo, &fakeCommandService{})
+
+	// An error status would make Meta redeliver the payload and answer its messages again
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestGetMessageWithoutWhatsAppIsUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/debug/whatsapp/messages/:messageId", NewWhatsAppHandler(nil).GetMessage)
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/whatsapp/messages/wamid.test", nil)
+	req = req.WithContext(log.Initialize(context.Background()))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusServiceUnavailable, recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestReceiveWebhookPassesTextMessagesToCommands(t *testing.T) {
+	body := loadPayload(t, "whatsapp_webhook_text_message.json")
+	commands := &fakeCommandService{}
+
+	recorder := postWebhookWith(t, testAppSecret, body, sign(testAppSecret, body), &fakeMessageRepository{}, commands)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	if len(commands.messages) != 1 {
+		t.Fatalf("expected 1 handled message, got %d", len(commands.messages))
+	}
+
+	message := commands.messages[0]
+	if message.From != "16505551234" {
+		t.Errorf("unexpected sender %q", message.From)
+	}
+	if message.Text != "Does it come in another color?" {
+		t.Errorf("unexpected text %q", message.Text)
+	}
+	if message.Latitude != nil || message.Longitude != nil {
+		t.Errorf("expected no location for a text message")
+	}
+}
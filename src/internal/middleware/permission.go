@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RequirePermission creates a middleware that checks if the roles of the user grant a global permission
+func RequirePermission(authz services.AuthorizationService, permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		claims, ok := requireClaims(c)
+		if !ok {
+			return
+		}
+
+		if err := authz.Authorize(ctx, claims, permission); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "insufficient permissions",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireBusinessPermission creates a middleware that checks if the user holds a permission on the
+// business identified by the given path parameter, as its owner or as a member
+func RequireBusinessPermission(authz services.AuthorizationService, permission models.Permission, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		claims, ok := requireClaims(c)
+		if !ok {
+			return
+		}
+
+		businessID, err := uuid.Parse(c.Param(param))
+		if err != nil {
+			log.Warn(ctx, "Invalid business ID format", zap.String(param, c.Param(param)))
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid business id",
+			})
+			c.Abort()
+			return
+		}
+
+		if err := authz.AuthorizeBusiness(ctx, claims, businessID, permission); err != nil {
+			switch err.Error() {
+			case "business not found":
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": "business not found",
+				})
+			case "insufficient permissions":
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": "insufficient permissions",
+				})
+			default:
+				log.Error(ctx, "Failed to authorize business access", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "internal server error",
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requireClaims extracts the JWT claims stored by AuthMiddleware, aborting the request if they are missing
+func requireClaims(c *gin.Context) (*models.JWTClaims, bool) {
+	ctx := c.Request.Context()
+
+	claims, ok := GetUserClaims(c)
+	if !ok {
+		log.Error(ctx, "User claims not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+		c.Abort()
+		return nil, false
+	}
+
+	return claims, true
+}
@@ -25,6 +25,17 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+// LogoutRequest represents the request to end the session of a refresh token
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// ChangePasswordRequest represents the request of an authenticated user to change their password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
 // RefreshTokenResponse represents the response after refreshing tokens
 type RefreshTokenResponse struct {
 	AccessToken  string `json:"access_token"`
@@ -59,3 +70,20 @@ const (
 	TokenTypeAccess  TokenType = "access"
 	TokenTypeRefresh TokenType = "refresh"
 )
+
+// JSONWebKey is a public key in JWK format (RFC 7517) used to verify token signatures
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA public exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+// JSONWebKeySet is the set of public keys that verify tokens issued by the API
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
@@ -6,66 +6,92 @@ import (
 	"github.com/google/uuid"
 )
 
+// DefaultTimezone is the timezone of a business until its opening hours are configured
+const DefaultTimezone = "UTC"
+
 // Business represents a business in the system
 type Business struct {
-	ID          uuid.UUID `json:"id"`
-	OwnerID     uuid.UUID `json:"owner_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Address     string    `json:"address"`
-	Phone       string    `json:"phone"`
-	Email       string    `json:"email"`
-	IsActive    bool      `json:"is_active"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                        uuid.UUID `json:"id"`
+	OwnerID                   uuid.UUID `json:"owner_id"`
+	Name                      string    `json:"name"`
+	Description               string    `json:"description"`
+	Address                   string    `json:"address"`
+	Latitude                  *float64  `json:"latitude,omitempty"`
+	Longitude                 *float64  `json:"longitude,omitempty"`
+	Timezone                  string    `json:"timezone"`
+	Phone                     string    `json:"phone"`
+	Email                     string    `json:"email"`
+	IsActive                  bool      `json:"is_active"`
+	CancellationNoticeMinutes int       `json:"cancellation_notice_minutes"` // Free cancellation window before an appointment
+	RequireVerifiedPhone      bool      `json:"require_verified_phone"`
+	CreatedAt                 time.Time `json:"created_at"`
+	UpdatedAt                 time.Time `json:"updated_at"`
 }
 
 // CreateBusinessRequest represents the request to create a business
 type CreateBusinessRequest struct {
-	Name        string `json:"name" binding:"required,min=3,max=255"`
-	Description string `json:"description" binding:"max=1000"`
-	Address     string `json:"address" binding:"max=500"`
-	Phone       string `json:"phone" binding:"required,min=10,max=50"`
-	Email       string `json:"email" binding:"omitempty,email"`
+	Name                 string   `json:"name" binding:"required,min=3,max=255"`
+	Description          string   `json:"description" binding:"max=1000"`
+	Address              string   `json:"address" binding:"max=500"`
+	Latitude             *float64 `json:"latitude" binding:"omitempty,min=-90,max=90,required_with=Longitude"`
+	Longitude            *float64 `json:"longitude" binding:"omitempty,min=-180,max=180,required_with=Latitude"`
+	Phone                string   `json:"phone" binding:"required,min=10,max=50"`
+	Email                string   `json:"email" binding:"omitempty,email"`
+	RequireVerifiedPhone bool     `json:"require_verified_phone"`
 }
 
 // UpdateBusinessRequest represents the request to update a business
 type UpdateBusinessRequest struct {
-	Name        string `json:"name" binding:"required,min=3,max=255"`
-	Description string `json:"description" binding:"max=1000"`
-	Address     string `json:"address" binding:"max=500"`
-	Phone       string `json:"phone" binding:"required,min=10,max=50"`
-	Email       string `json:"email" binding:"omitempty,email"`
-	IsActive    *bool  `json:"is_active"`
+	Name                 string   `json:"name" binding:"required,min=3,max=255"`
+	Description          string   `json:"description" binding:"max=1000"`
+	Address              string   `json:"address" binding:"max=500"`
+	Latitude             *float64 `json:"latitude" binding:"omitempty,min=-90,max=90,required_with=Longitude"`
+	Longitude            *float64 `json:"longitude" binding:"omitempty,min=-180,max=180,required_with=Latitude"`
+	Phone                string   `json:"phone" binding:"required,min=10,max=50"`
+	Email                string   `json:"email" binding:"omitempty,email"`
+	IsActive             *bool    `json:"is_active"`
+	RequireVerifiedPhone *bool    `json:"require_verified_phone"`
 }
 
 // BusinessResponse represents the response with business data
 type BusinessResponse struct {
-	ID          uuid.UUID `json:"id"`
-	OwnerID     uuid.UUID `json:"owner_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Address     string    `json:"address"`
-	Phone       string    `json:"phone"`
-	Email       string    `json:"email"`
-	IsActive    bool      `json:"is_active"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                        uuid.UUID  `json:"id"`
+	OwnerID                   uuid.UUID  `json:"owner_id"`
+	Name                      string     `json:"name"`
+	Description               string     `json:"description"`
+	Address                   string     `json:"address"`
+	Latitude                  *float64   `json:"latitude,omitempty"`
+	Longitude                 *float64   `json:"longitude,omitempty"`
+	Timezone                  string     `json:"timezone"`
+	Phone                     string     `json:"phone"`
+	Email                     string     `json:"email"`
+	IsActive                  bool       `json:"is_active"`
+	IsOpenNow                 bool       `json:"is_open_now"`
+	CancellationNoticeMinutes int        `json:"cancellation_notice_minutes"`
+	RequireVerifiedPhone      bool       `json:"require_verified_phone"`
+	NextOpeningAt             *time.Time `json:"next_opening_at,omitempty"` // Set while the business is closed
+	CreatedAt                 time.Time  `json:"created_at"`
+	UpdatedAt                 time.Time  `json:"updated_at"`
 }
 
 // ToResponse converts a Business to BusinessResponse
 func (b *Business) ToResponse() *BusinessResponse {
 	return &BusinessResponse{
-		ID:          b.ID,
-		OwnerID:     b.OwnerID,
-		Name:        b.Name,
-		Description: b.Description,
-		Address:     b.Address,
-		Phone:       b.Phone,
-		Email:       b.Email,
-		IsActive:    b.IsActive,
-		CreatedAt:   b.CreatedAt,
-		UpdatedAt:   b.UpdatedAt,
+		ID:                        b.ID,
+		OwnerID:                   b.OwnerID,
+		Name:                      b.Name,
+		Description:               b.Description,
+		Address:                   b.Address,
+		Latitude:                  b.Latitude,
+		Longitude:                 b.Longitude,
+		Timezone:                  b.Timezone,
+		Phone:                     b.Phone,
+		Email:                     b.Email,
+		IsActive:                  b.IsActive,
+		CancellationNoticeMinutes: b.CancellationNoticeMinutes,
+		RequireVerifiedPhone:      b.RequireVerifiedPhone,
+		CreatedAt:                 b.CreatedAt,
+		UpdatedAt:                 b.UpdatedAt,
 	}
 }
 
@@ -73,28 +99,48 @@ func (b *Business) ToResponse() *BusinessResponse {
 func (req *CreateBusinessRequest) ToBusiness(ownerID uuid.UUID) *Business {
 	now := time.Now()
 	return &Business{
-		ID:          uuid.New(),
-		OwnerID:     ownerID,
-		Name:        req.Name,
-		Description: req.Description,
-		Address:     req.Address,
-		Phone:       req.Phone,
-		Email:       req.Email,
-		IsActive:    true,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:                   uuid.New(),
+		OwnerID:              ownerID,
+		Name:                 req.Name,
+		Description:          req.Description,
+		Address:              req.Address,
+		Latitude:             req.Latitude,
+		Longitude:            req.Longitude,
+		Timezone:             DefaultTimezone,
+		Phone:                req.Phone,
+		Email:                req.Email,
+		IsActive:             true,
+		RequireVerifiedPhone: req.RequireVerifiedPhone,
+		CreatedAt:            now,
+		UpdatedAt:            now,
 	}
 }
 
+// ApplySchedule fills in whether the business is open at the given time and, if not, when it opens next
+func (r *BusinessResponse) ApplySchedule(schedule *BusinessSchedule, at time.Time) {
+	r.IsOpenNow = schedule.IsOpenAt(at)
+	r.NextOpeningAt = schedule.NextOpening(at)
+}
+
+// HasLocation checks if the business has its coordinates configured
+func (b *Business) HasLocation() bool {
+	return b.Latitude != nil && b.Longitude != nil
+}
+
 // ApplyUpdateRequest applies UpdateBusinessRequest to an existing Business
 func (b *Business) ApplyUpdateRequest(req *UpdateBusinessRequest) {
 	b.Name = req.Name
 	b.Description = req.Description
 	b.Address = req.Address
+	b.Latitude = req.Latitude
+	b.Longitude = req.Longitude
 	b.Phone = req.Phone
 	b.Email = req.Email
 	if req.IsActive != nil {
 		b.IsActive = *req.IsActive
 	}
+	if req.RequireVerifiedPhone != nil {
+		b.RequireVerifiedPhone = *req.RequireVerifiedPhone
+	}
 	b.UpdatedAt = time.Now()
 }
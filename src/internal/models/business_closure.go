@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BusinessClosure represents a period in which a business is closed regardless of its opening hours
+type BusinessClosure struct {
+	ID         uuid.UUID `json:"id"`
+	BusinessID uuid.UUID `json:"business_id"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateBusinessClosureRequest represents the request to add a holiday or exceptional closure
+type CreateBusinessClosureRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Reason   string    `json:"reason" binding:"max=255"`
+}
+
+// Validate checks that the closure period is not empty
+func (req *CreateBusinessClosureRequest) Validate() error {
+	if !req.EndsAt.After(req.StartsAt) {
+		return fmt.Errorf("invalid closure period")
+	}
+	return nil
+}
+
+// ToBusinessClosure converts CreateBusinessClosureRequest to BusinessClosure
+func (req *CreateBusinessClosureRequest) ToBusinessClosure(businessID uuid.UUID) *BusinessClosure {
+	return &BusinessClosure{
+		ID:         uuid.New(),
+		BusinessID: businessID,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		Reason:     req.Reason,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Slot returns the period covered by the closure
+func (c *BusinessClosure) Slot() TimeSlot {
+	return TimeSlot{StartAt: c.StartsAt, EndAt: c.EndsAt}
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BusinessMember represents a user who works at a business besides its owner
+type BusinessMember struct {
+	BusinessID uuid.UUID    `json:"business_id"`
+	UserID     uuid.UUID    `json:"user_id"`
+	Role       BusinessRole `json:"role"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// SetBusinessMemberRequest represents the request to add a member to a business or change their role
+type SetBusinessMemberRequest struct {
+	Role BusinessRole `json:"role" binding:"required,oneof=manager staff"`
+}
+
+// ToBusinessMember converts SetBusinessMemberRequest to BusinessMember
+func (req *SetBusinessMemberRequest) ToBusinessMember(businessID, userID uuid.UUID) *BusinessMember {
+	now := time.Now()
+	return &BusinessMember{
+		BusinessID: businessID,
+		UserID:     userID,
+		Role:       req.Role,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SetCancellationPolicyRequest represents the request to configure the cancellation policy of a business
+type SetCancellationPolicyRequest struct {
+	NoticeMinutes *int `json:"notice_minutes" binding:"required,min=0,max=10080"`
+}
+
+// CancellationPolicyResponse represents the cancellation policy of a business
+type CancellationPolicyResponse struct {
+	BusinessID    uuid.UUID `json:"business_id"`
+	NoticeMinutes int       `json:"notice_minutes"` // Appointments may be cancelled or moved for free until this many minutes before they start
+}
+
+// CancellationPolicy returns the cancellation policy of the business
+func (b *Business) CancellationPolicy() *CancellationPolicyResponse {
+	return &CancellationPolicyResponse{
+		BusinessID:    b.ID,
+		NoticeMinutes: b.CancellationNoticeMinutes,
+	}
+}
+
+// IsLateChange checks if cancelling or moving the ticket at the given time breaks the
+// cancellation policy. A ticket that has already been called is always late; a waiting
+// appointment is late once its notice window has started; a waiting walk-in never is.
+func (b *Business) IsLateChange(ticket *Ticket, at time.Time) bool {
+	if ticket.Status != TicketStatusWaiting {
+		return true
+	}
+	if !ticket.IsAppointment() {
+		return false
+	}
+	deadline := ticket.ScheduledAt.Add(-time.Duration(b.CancellationNoticeMinutes) * time.Minute)
+	return !at.Before(deadline)
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DomainEventType identifies what happened in a domain event
+type DomainEventType string
+
+const (
+	DomainEventTicketNoShow      DomainEventType = "ticket.no_show"
+	DomainEventTicketCancelled   DomainEventType = "ticket.cancelled"
+	DomainEventTicketRescheduled DomainEventType = "ticket.rescheduled"
+)
+
+// DomainEvent represents something that happened in the domain that other parts of the system react to
+type DomainEvent struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       DomainEventType `json:"event_type"`
+	BusinessID uuid.UUID       `json:"business_id"`
+	TicketID   *uuid.UUID      `json:"ticket_id,omitempty"`
+	UserID     *uuid.UUID      `json:"user_id,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// TicketEventPayload is the payload of events about a ticket
+type TicketEventPayload struct {
+	QueueID          uuid.UUID    `json:"queue_id"`
+	Number           int          `json:"number"`
+	Status           TicketStatus `json:"status"`
+	ScheduledAt      *time.Time   `json:"scheduled_at,omitempty"`
+	LateCancellation bool         `json:"late_cancellation,omitempty"`
+}
+
+// NewTicketEvent creates a domain event about a ticket and its customer
+func NewTicketEvent(eventType DomainEventType, ticket *Ticket, at time.Time) *DomainEvent {
+	payload, _ := json.Marshal(TicketEventPayload{
+		QueueID:          ticket.QueueID,
+		Number:           ticket.Number,
+		Status:           ticket.Status,
+		ScheduledAt:      ticket.ScheduledAt,
+		LateCancellation: ticket.LateCancellation,
+	})
+
+	return &DomainEvent{
+		ID:         uuid.New(),
+		Type:       eventType,
+		BusinessID: ticket.BusinessID,
+		TicketID:   &ticket.ID,
+		UserID:     &ticket.CustomerID,
+		Payload:    payload,
+		OccurredAt: at,
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// LoginThrottleScope identifies what failed login attempts are counted against
+type LoginThrottleScope string
+
+const (
+	LoginThrottleScopeEmail LoginThrottleScope = "email"
+	LoginThrottleScopeIP    LoginThrottleScope = "ip"
+)
+
+// LoginThrottle tracks the recent failed login attempts for an email or a client IP
+type LoginThrottle struct {
+	Scope          LoginThrottleScope
+	Key            string
+	FailedAttempts int
+	LastFailedAt   time.Time
+	LockedUntil    *time.Time
+}
+
+// RetryAfter returns how long logins for the key stay blocked, or zero if they are allowed
+func (t *LoginThrottle) RetryAfter(now time.Time) time.Duration {
+	if t.LockedUntil == nil || !now.Before(*t.LockedUntil) {
+		return 0
+	}
+	return t.LockedUntil.Sub(now)
+}
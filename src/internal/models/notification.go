@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationStatus represents the delivery state of a queued notification
+type NotificationStatus string
+
+const (
+	NotificationStatusPending NotificationStatus = "pending" // Waiting to be sent or retried
+	NotificationStatusSent    NotificationStatus = "sent"    // Accepted by the Graph API
+	NotificationStatusDead    NotificationStatus = "dead"    // Given up after the last attempt failed
+)
+
+// Notification is a WhatsApp message queued in the outbox, sent in the background
+// and retried until it is accepted by the Graph API
+type Notification struct {
+	ID            uuid.UUID                  `json:"id"`
+	Message       SendWhatsAppMessageRequest `json:"message"`
+	BusinessID    *uuid.UUID                 `json:"business_id,omitempty"` // Business the message is about, if any
+	TicketID      *uuid.UUID                 `json:"ticket_id,omitempty"`   // Ticket the message is about, if any
+	Status        NotificationStatus         `json:"status"`
+	Attempts      int                        `json:"attempts"`
+	NextAttemptAt time.Time                  `json:"next_attempt_at"`
+	LastError     *string                    `json:"last_error,omitempty"`
+	MessageID     *string                    `json:"message_id,omitempty"`
+	SentAt        *time.Time                 `json:"sent_at,omitempty"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+}
+
+// NewNotification creates a notification due to be sent immediately
+func NewNotification(message SendWhatsAppMessageRequest) *Notification {
+	now := time.Now()
+	return &Notification{
+		ID:            uuid.New(),
+		Message:       message,
+		Status:        NotificationStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
@@ -0,0 +1,267 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// clockLayout is the format of opening and closing times
+const clockLayout = "15:04"
+
+// OpeningHours represents an interval in which a business is open on a day of the week
+type OpeningHours struct {
+	ID         uuid.UUID    `json:"id"`
+	BusinessID uuid.UUID    `json:"business_id"`
+	Weekday    time.Weekday `json:"weekday"`
+	OpensAt    string       `json:"opens_at"`  // Local time, HH:MM
+	ClosesAt   string       `json:"closes_at"` // Local time, HH:MM
+}
+
+// OpeningHoursEntry represents one opening interval in a schedule request
+type OpeningHoursEntry struct {
+	Weekday  time.Weekday `json:"weekday" binding:"min=0,max=6"`
+	OpensAt  string       `json:"opens_at" binding:"required"`
+	ClosesAt string       `json:"closes_at" binding:"required"`
+}
+
+// SetOpeningHoursRequest represents the request to replace the weekly schedule of a business
+type SetOpeningHoursRequest struct {
+	Timezone string              `json:"timezone" binding:"required"`
+	Hours    []OpeningHoursEntry `json:"hours" binding:"dive"`
+}
+
+// OpeningHoursResponse represents the weekly schedule of a business
+type OpeningHoursResponse struct {
+	BusinessID uuid.UUID       `json:"business_id"`
+	Timezone   string          `json:"timezone"`
+	Hours      []*OpeningHours `json:"hours"`
+}
+
+// Validate checks the timezone and that every interval is well formed and does not overlap another one
+func (req *SetOpeningHoursRequest) Validate() error {
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone")
+	}
+
+	byDay := make(map[time.Weekday][][2]time.Duration)
+	for _, entry := range req.Hours {
+		opens, err := parseClock(entry.OpensAt)
+		if err != nil {
+			return fmt.Errorf("invalid opening hours")
+		}
+		closes, err := parseClock(entry.ClosesAt)
+		if err != nil || opens >= closes {
+			return fmt.Errorf("invalid opening hours")
+		}
+		byDay[entry.Weekday] = append(byDay[entry.Weekday], [2]time.Duration{opens, closes})
+	}
+
+	for _, intervals := range byDay {
+		sort.Slice(intervals, func(i, j int) bool { return intervals[i][0] < intervals[j][0] })
+		for i := 1; i < len(intervals); i++ {
+			if intervals[i][0] < intervals[i-1][1] {
+				return fmt.Errorf("invalid opening hours")
+			}
+		}
+	}
+
+	return nil
+}
+
+// ToOpeningHours converts the request entries to OpeningHours of a business
+func (req *SetOpeningHoursRequest) ToOpeningHours(businessID uuid.UUID) []*OpeningHours {
+	hours := make([]*OpeningHours, len(req.Hours))
+	for i, entry := range req.Hours {
+		hours[i] = &OpeningHours{
+			ID:         uuid.New(),
+			BusinessID: businessID,
+			Weekday:    entry.Weekday,
+			OpensAt:    entry.OpensAt,
+			ClosesAt:   entry.ClosesAt,
+		}
+	}
+	return hours
+}
+
+// parseClock parses an HH:MM time into the duration since midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse(clockLayout, value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// TimeSlot represents a period of time
+type TimeSlot struct {
+	StartAt time.Time `json:"start_at"`
+	EndAt   time.Time `json:"end_at"`
+}
+
+// Contains checks if the other slot lies entirely within this one
+func (s TimeSlot) Contains(other TimeSlot) bool {
+	return !other.StartAt.Before(s.StartAt) && !other.EndAt.After(s.EndAt)
+}
+
+// Overlaps checks if two slots share any time
+func (s TimeSlot) Overlaps(other TimeSlot) bool {
+	return s.StartAt.Before(other.EndAt) && other.StartAt.Before(s.EndAt)
+}
+
+// scheduleHorizonDays bounds how far ahead the next opening of a business is searched
+const scheduleHorizonDays = 31
+
+// BusinessSchedule combines the timezone, weekly opening hours and closures of a business.
+// A business without opening hours is considered always open, except during closures.
+type BusinessSchedule struct {
+	Location *time.Location
+	Hours    []*OpeningHours
+	Closures []*BusinessClosure
+}
+
+// NewBusinessSchedule builds the schedule of a business from its timezone, opening hours and closures
+func NewBusinessSchedule(business *Business, hours []*OpeningHours, closures []*BusinessClosure) (*BusinessSchedule, error) {
+	location, err := time.LoadLocation(business.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone")
+	}
+	return &BusinessSchedule{Location: location, Hours: hours, Closures: closures}, nil
+}
+
+// IntervalsOn returns the opening intervals of the local day containing day, in chronological
+// order, with closures cut out. Without opening hours the whole day is open.
+func (s *BusinessSchedule) IntervalsOn(day time.Time) []TimeSlot {
+	local := day.In(s.Location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.Location)
+
+	var intervals []TimeSlot
+	if len(s.Hours) == 0 {
+		intervals = append(intervals, TimeSlot{
+			StartAt: midnight,
+			EndAt:   time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, s.Location),
+		})
+	}
+	for _, h := range s.Hours {
+		if h.Weekday != local.Weekday() {
+			continue
+		}
+		opens, err := parseClock(h.OpensAt)
+		if err != nil {
+			continue
+		}
+		closes, err := parseClock(h.ClosesAt)
+		if err != nil {
+			continue
+		}
+		intervals = append(intervals, TimeSlot{
+			StartAt: wallClock(midnight, opens),
+			EndAt:   wallClock(midnight, closes),
+		})
+	}
+
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].StartAt.Before(intervals[j].StartAt) })
+
+	for _, closure := range s.Closures {
+		intervals = subtractSlot(intervals, closure.Slot())
+	}
+	return intervals
+}
+
+// IsOpenAt checks if the business is open at the given time
+func (s *BusinessSchedule) IsOpenAt(at time.Time) bool {
+	if len(s.Hours) == 0 {
+		return s.closureAt(at) == nil
+	}
+	for _, interval := range s.IntervalsOn(at) {
+		if !at.Before(interval.StartAt) && at.Before(interval.EndAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpening returns when the business opens next after a time it is closed.
+// It returns nil if the business is open at that time or does not open within the search horizon.
+func (s *BusinessSchedule) NextOpening(from time.Time) *time.Time {
+	if s.IsOpenAt(from) {
+		return nil
+	}
+
+	if len(s.Hours) == 0 {
+		// Always open outside closures: reopen when the closures covering from end
+		at := from
+		for closure := s.closureAt(at); closure != nil; closure = s.closureAt(at) {
+			at = closure.EndsAt
+		}
+		return &at
+	}
+
+	local := from.In(s.Location)
+	for d := 0; d <= scheduleHorizonDays; d++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+d, 12, 0, 0, 0, s.Location)
+		for _, interval := range s.IntervalsOn(day) {
+			if interval.StartAt.After(from) {
+				opening := interval.StartAt
+				return &opening
+			}
+		}
+	}
+	return nil
+}
+
+// closureAt returns the closure in effect at the given time, if any
+func (s *BusinessSchedule) closureAt(at time.Time) *BusinessClosure {
+	for _, closure := range s.Closures {
+		if !at.Before(closure.StartsAt) && at.Before(closure.EndsAt) {
+			return closure
+		}
+	}
+	return nil
+}
+
+// subtractSlot removes a period from a list of slots, splitting slots that contain it
+func subtractSlot(slots []TimeSlot, cut TimeSlot) []TimeSlot {
+	var result []TimeSlot
+	for _, slot := range slots {
+		if !slot.Overlaps(cut) {
+			result = append(result, slot)
+			continue
+		}
+		if slot.StartAt.Before(cut.StartAt) {
+			result = append(result, TimeSlot{StartAt: slot.StartAt, EndAt: cut.StartAt})
+		}
+		if slot.EndAt.After(cut.EndAt) {
+			result = append(result, TimeSlot{StartAt: cut.EndAt, EndAt: slot.EndAt})
+		}
+	}
+	return result
+}
+
+// Covers checks if a slot lies entirely within one opening interval.
+// Without opening hours any slot outside closures is covered, like IsOpenAt.
+func (s *BusinessSchedule) Covers(slot TimeSlot) bool {
+	if len(s.Hours) == 0 {
+		for _, closure := range s.Closures {
+			if slot.Overlaps(closure.Slot()) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, interval := range s.IntervalsOn(slot.StartAt) {
+		if interval.Contains(slot) {
+			return true
+		}
+	}
+	return false
+}
+
+// wallClock returns the time at the given offset from midnight, keeping wall-clock
+// semantics across daylight saving changes
+func wallClock(midnight time.Time, offset time.Duration) time.Time {
+	return time.Date(midnight.Year(), midnight.Month(), midnight.Day(),
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, midnight.Location())
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// testSchedule builds a schedule in UTC from opening hours and closures
+func testSchedule(hours []*OpeningHours, closures []*BusinessClosure) *BusinessSchedule {
+	return &BusinessSchedule{Location: time.UTC, Hours: hours, Closures: closures}
+}
+
+func TestScheduleWithoutHoursCoversAnySlot(t *testing.T) {
+	schedule := testSchedule(nil, nil)
+	slot := TimeSlot{
+		StartAt: time.Date(2026, 10, 19, 23, 30, 0, 0, time.UTC),
+		EndAt:   time.Date(2026, 10, 20, 0, 30, 0, 0, time.UTC),
+	}
+
+	if !schedule.IsOpenAt(slot.StartAt) {
+		t.Fatalf("expected a business without opening hours to be open")
+	}
+	if !schedule.Covers(slot) {
+		t.Errorf("expected a business without opening hours to cover %v - %v", slot.StartAt, slot.EndAt)
+	}
+	if intervals := schedule.IntervalsOn(slot.StartAt); len(intervals) != 1 {
+		t.Errorf("expected the whole day to be open, got %d intervals", len(intervals))
+	}
+}
+
+func TestScheduleWithoutHoursExcludesClosures(t *testing.T) {
+	closure := &BusinessClosure{
+		StartsAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC),
+	}
+	schedule := testSchedule(nil, []*BusinessClosure{closure})
+
+	during := TimeSlot{
+		StartAt: time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC),
+		EndAt:   time.Date(2026, 10, 19, 13, 30, 0, 0, time.UTC),
+	}
+	if schedule.IsOpenAt(during.StartAt) || schedule.Covers(during) {
+		t.Errorf("expected the closure to close the business")
+	}
+
+	overlapping := TimeSlot{
+		StartAt: time.Date(2026, 10, 19, 11, 30, 0, 0, time.UTC),
+		EndAt:   time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC),
+	}
+	if schedule.Covers(overlapping) {
+		t.Errorf("expected a slot overlapping the closure not to be covered")
+	}
+
+	after := TimeSlot{
+		StartAt: time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC),
+		EndAt:   time.Date(2026, 10, 19, 14, 30, 0, 0, time.UTC),
+	}
+	if !schedule.Covers(after) {
+		t.Errorf("expected a slot after the closure to be covered")
+	}
+}
+
+func TestScheduleWithHoursCoversSlotsWithinHours(t *testing.T) {
+	monday := &OpeningHours{Weekday: time.Monday, OpensAt: "09:00", ClosesAt: "17:00"}
+	schedule := testSchedule([]*OpeningHours{monday}, nil)
+
+	inside := TimeSlot{
+		StartAt: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+		EndAt:   time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC),
+	}
+	if !schedule.Covers(inside) {
+		t.Errorf("expected a slot within opening hours to be covered")
+	}
+
+	outside := TimeSlot{
+		StartAt: time.Date(2026, 10, 19, 16, 45, 0, 0, time.UTC),
+		EndAt:   time.Date(2026, 10, 19, 17, 15, 0, 0, time.UTC),
+	}
+	if schedule.Covers(outside) {
+		t.Errorf("expected a slot past closing time not to be covered")
+	}
+}
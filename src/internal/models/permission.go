@@ -0,0 +1,124 @@
+package models
+
+// Permission is an action a user may perform. Global permissions are granted by user roles;
+// business permissions are granted on a single business by owning it or being a member of it,
+// and read-only ones on every business by user roles.
+type Permission string
+
+// Global permissions
+const (
+	PermissionBusinessCreate  Permission = "business:create"
+	PermissionBusinessListOwn Permission = "business:list_own"
+	PermissionBusinessListAll Permission = "business:list_all"
+	PermissionTicketJoin      Permission = "ticket:join"
+	PermissionTicketManageOwn Permission = "ticket:manage_own"
+	PermissionUserList        Permission = "user:list"
+	PermissionUserCreate      Permission = "user:create"
+	PermissionUserDeactivate  Permission = "user:deactivate"
+	PermissionUserChangeRoles Permission = "user:change_roles"
+	PermissionUserDelete      Permission = "user:delete"
+	PermissionSystemDebug     Permission = "system:debug"
+)
+
+// Business permissions
+const (
+	PermissionBusinessView          Permission = "business:view"
+	PermissionBusinessUpdate        Permission = "business:update"
+	PermissionBusinessDelete        Permission = "business:delete"
+	PermissionBusinessManageMembers Permission = "business:manage_members"
+	PermissionCatalogManage         Permission = "catalog:manage"
+	PermissionScheduleManage        Permission = "schedule:manage"
+	PermissionQueueView             Permission = "queue:view"
+	PermissionQueueViewTickets      Permission = "queue:view_tickets"
+	PermissionQueueManage           Permission = "queue:manage"
+	PermissionQueueCallNext         Permission = "queue:call_next"
+)
+
+// rolePermissions maps each user role to the global permissions it grants
+var rolePermissions = map[UserRole][]Permission{
+	RoleBusinessOwner: {
+		PermissionBusinessCreate,
+		PermissionBusinessListOwn,
+	},
+	RoleCustomer: {
+		PermissionTicketJoin,
+		PermissionTicketManageOwn,
+		PermissionBusinessView, // Find businesses and their queues before joining one
+		PermissionQueueView,
+	},
+	RoleAdmin: {
+		PermissionBusinessListAll,
+		PermissionUserList,
+		PermissionUserCreate,
+		PermissionUserDeactivate,
+		PermissionUserChangeRoles,
+		PermissionUserDelete,
+		PermissionSystemDebug,
+	},
+}
+
+// BusinessRole is the role of a user within a single business
+type BusinessRole string
+
+const (
+	BusinessRoleOwner   BusinessRole = "owner"   // Owns the business; not stored as a membership
+	BusinessRoleManager BusinessRole = "manager" // Runs the business day to day
+	BusinessRoleStaff   BusinessRole = "staff"   // Serves customers at the queue console
+)
+
+// businessRolePermissions maps each business role to the permissions it grants on the business
+var businessRolePermissions = map[BusinessRole][]Permission{
+	BusinessRoleOwner: {
+		PermissionBusinessView,
+		PermissionBusinessUpdate,
+		PermissionBusinessDelete,
+		PermissionBusinessManageMembers,
+		PermissionCatalogManage,
+		PermissionScheduleManage,
+		PermissionQueueView,
+		PermissionQueueViewTickets,
+		PermissionQueueManage,
+		PermissionQueueCallNext,
+	},
+	BusinessRoleManager: {
+		PermissionBusinessView,
+		PermissionBusinessUpdate,
+		PermissionCatalogManage,
+		PermissionScheduleManage,
+		PermissionQueueView,
+		PermissionQueueViewTickets,
+		PermissionQueueManage,
+		PermissionQueueCallNext,
+	},
+	BusinessRoleStaff: {
+		PermissionBusinessView,
+		PermissionQueueView,
+		PermissionQueueViewTickets,
+		PermissionQueueCallNext,
+	},
+}
+
+// RolesGrant checks if any of the user roles grants the global permission
+func RolesGrant(roles []UserRole, permission Permission) bool {
+	for _, role := range roles {
+		if containsPermission(rolePermissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Grants checks if the business role grants the permission
+func (r BusinessRole) Grants(permission Permission) bool {
+	return containsPermission(businessRolePermissions[r], permission)
+}
+
+// containsPermission checks if a permission is in the list
+func containsPermission(permissions []Permission, permission Permission) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Queue represents a virtual queue managed by a business
+type Queue struct {
+	ID                    uuid.UUID  `json:"id"`
+	BusinessID            uuid.UUID  `json:"business_id"`
+	ServiceID             *uuid.UUID `json:"service_id,omitempty"`
+	Name                  string     `json:"name"`
+	Description           string     `json:"description"`
+	AverageServiceMinutes int        `json:"average_service_minutes"`
+	IsActive              bool       `json:"is_active"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// CreateQueueRequest represents the request to create a queue
+type CreateQueueRequest struct {
+	Name                  string     `json:"name" binding:"required,min=2,max=255"`
+	Description           string     `json:"description" binding:"max=1000"`
+	ServiceID             *uuid.UUID `json:"service_id"`
+	AverageServiceMinutes int        `json:"average_service_minutes" binding:"required,min=1,max=480"`
+}
+
+// UpdateQueueRequest represents the request to update a queue
+type UpdateQueueRequest struct {
+	Name                  string     `json:"name" binding:"required,min=2,max=255"`
+	Description           string     `json:"description" binding:"max=1000"`
+	ServiceID             *uuid.UUID `json:"service_id"`
+	AverageServiceMinutes int        `json:"average_service_minutes" binding:"required,min=1,max=480"`
+	IsActive              *bool      `json:"is_active"`
+}
+
+// QueueResponse represents the response with queue data
+type QueueResponse struct {
+	ID                    uuid.UUID  `json:"id"`
+	BusinessID            uuid.UUID  `json:"business_id"`
+	ServiceID             *uuid.UUID `json:"service_id,omitempty"`
+	Name                  string     `json:"name"`
+	Description           string     `json:"description"`
+	AverageServiceMinutes int        `json:"average_service_minutes"`
+	IsActive              bool       `json:"is_active"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// ToResponse converts a Queue to QueueResponse
+func (q *Queue) ToResponse() *QueueResponse {
+	return &QueueResponse{
+		ID:                    q.ID,
+		BusinessID:            q.BusinessID,
+		ServiceID:             q.ServiceID,
+		Name:                  q.Name,
+		Description:           q.Description,
+		AverageServiceMinutes: q.AverageServiceMinutes,
+		IsActive:              q.IsActive,
+		CreatedAt:             q.CreatedAt,
+		UpdatedAt:             q.UpdatedAt,
+	}
+}
+
+// ToQueue converts CreateQueueRequest to Queue
+func (req *CreateQueueRequest) ToQueue(businessID uuid.UUID) *Queue {
+	now := time.Now()
+	return &Queue{
+		ID:                    uuid.New(),
+		BusinessID:            businessID,
+		ServiceID:             req.ServiceID,
+		Name:                  req.Name,
+		Description:           req.Description,
+		AverageServiceMinutes: req.AverageServiceMinutes,
+		IsActive:              true,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+}
+
+// ApplyUpdateRequest applies UpdateQueueRequest to an existing Queue
+func (q *Queue) ApplyUpdateRequest(req *UpdateQueueRequest) {
+	q.Name = req.Name
+	q.Description = req.Description
+	q.ServiceID = req.ServiceID
+	q.AverageServiceMinutes = req.AverageServiceMinutes
+	if req.IsActive != nil {
+		q.IsActive = *req.IsActive
+	}
+	q.UpdatedAt = time.Now()
+}
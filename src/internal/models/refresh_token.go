@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is the server-side record of an issued refresh token, identified by its JWT ID.
+// Tokens rotated from the same login share a family.
+type RefreshToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	FamilyID   uuid.UUID
+	ExpiresAt  time.Time
+	UsedAt     *time.Time
+	ReplacedBy *uuid.UUID
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// NewRefreshToken creates the record of a refresh token issued to a user, starting a new token family
+func NewRefreshToken(userID uuid.UUID, ttl time.Duration) *RefreshToken {
+	now := time.Now()
+	return &RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		FamilyID:  uuid.New(),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// MaxReputationScore is the score of a user without any missed or late-cancelled ticket
+	MaxReputationScore = 100
+
+	// noShowWeight and lateCancellationWeight set how much each kind of failure
+	// counts against a completed ticket
+	noShowWeight           = 1.0
+	lateCancellationWeight = 0.5
+)
+
+// TicketOutcomes counts the finished tickets of a customer that affect their reputation
+type TicketOutcomes struct {
+	Completed         int
+	LateCancellations int
+	NoShows           int
+}
+
+// Reputation represents how reliable a customer is at showing up for their tickets
+type Reputation struct {
+	Score             int        `json:"score"`
+	CompletedTickets  int        `json:"completed_tickets"`
+	LateCancellations int        `json:"late_cancellations"`
+	NoShows           int        `json:"no_shows"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// NewReputation computes the reputation for the given ticket outcomes.
+// The score is the weighted share of completed tickets, smoothed so that
+// new customers start at the maximum and a single failure does not zero it.
+func NewReputation(outcomes TicketOutcomes, at time.Time) Reputation {
+	successes := float64(outcomes.Completed) + 1
+	failures := float64(outcomes.NoShows)*noShowWeight + float64(outcomes.LateCancellations)*lateCancellationWeight
+
+	return Reputation{
+		Score:             int(math.Round(MaxReputationScore * successes / (successes + failures))),
+		CompletedTickets:  outcomes.Completed,
+		LateCancellations: outcomes.LateCancellations,
+		NoShows:           outcomes.NoShows,
+		UpdatedAt:         &at,
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceOffering represents a service in the catalog of a business
+type ServiceOffering struct {
+	ID                     uuid.UUID `json:"id"`
+	BusinessID             uuid.UUID `json:"business_id"`
+	Name                   string    `json:"name"`
+	Description            string    `json:"description"`
+	AverageDurationMinutes int       `json:"average_duration_minutes"`
+	PriceCents             int64     `json:"price_cents"`
+	CheckInRadiusMeters    int       `json:"check_in_radius_meters"`
+	LateToleranceMinutes   int       `json:"late_tolerance_minutes"`
+	IsActive               bool      `json:"is_active"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// CreateServiceOfferingRequest represents the request to add a service to a business catalog
+type CreateServiceOfferingRequest struct {
+	Name                   string `json:"name" binding:"required,min=2,max=255"`
+	Description            string `json:"description" binding:"max=1000"`
+	AverageDurationMinutes int    `json:"average_duration_minutes" binding:"required,min=1,max=480"`
+	PriceCents             int64  `json:"price_cents" binding:"min=0"`
+	CheckInRadiusMeters    int    `json:"check_in_radius_meters" binding:"min=0,max=100000"`
+	LateToleranceMinutes   int    `json:"late_tolerance_minutes" binding:"min=0,max=240"`
+}
+
+// UpdateServiceOfferingRequest represents the request to update a service of a business catalog
+type UpdateServiceOfferingRequest struct {
+	Name                   string `json:"name" binding:"required,min=2,max=255"`
+	Description            string `json:"description" binding:"max=1000"`
+	AverageDurationMinutes int    `json:"average_duration_minutes" binding:"required,min=1,max=480"`
+	PriceCents             int64  `json:"price_cents" binding:"min=0"`
+	CheckInRadiusMeters    int    `json:"check_in_radius_meters" binding:"min=0,max=100000"`
+	LateToleranceMinutes   int    `json:"late_tolerance_minutes" binding:"min=0,max=240"`
+	IsActive               *bool  `json:"is_active"`
+}
+
+// ServiceOfferingResponse represents the response with service offering data
+type ServiceOfferingResponse struct {
+	ID                     uuid.UUID `json:"id"`
+	BusinessID             uuid.UUID `json:"business_id"`
+	Name                   string    `json:"name"`
+	Description            string    `json:"description"`
+	AverageDurationMinutes int       `json:"average_duration_minutes"`
+	PriceCents             int64     `json:"price_cents"`
+	CheckInRadiusMeters    int       `json:"check_in_radius_meters"`
+	LateToleranceMinutes   int       `json:"late_tolerance_minutes"`
+	IsActive               bool      `json:"is_active"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// ToResponse converts a ServiceOffering to ServiceOfferingResponse
+func (o *ServiceOffering) ToResponse() *ServiceOfferingResponse {
+	return &ServiceOfferingResponse{
+		ID:                     o.ID,
+		BusinessID:             o.BusinessID,
+		Name:                   o.Name,
+		Description:            o.Description,
+		AverageDurationMinutes: o.AverageDurationMinutes,
+		PriceCents:             o.PriceCents,
+		CheckInRadiusMeters:    o.CheckInRadiusMeters,
+		LateToleranceMinutes:   o.LateToleranceMinutes,
+		IsActive:               o.IsActive,
+		CreatedAt:              o.CreatedAt,
+		UpdatedAt:              o.UpdatedAt,
+	}
+}
+
+// ToServiceOffering converts CreateServiceOfferingRequest to ServiceOffering
+func (req *CreateServiceOfferingRequest) ToServiceOffering(businessID uuid.UUID) *ServiceOffering {
+	now := time.Now()
+	return &ServiceOffering{
+		ID:                     uuid.New(),
+		BusinessID:             businessID,
+		Name:                   req.Name,
+		Description:            req.Description,
+		AverageDurationMinutes: req.AverageDurationMinutes,
+		PriceCents:             req.PriceCents,
+		CheckInRadiusMeters:    req.CheckInRadiusMeters,
+		LateToleranceMinutes:   req.LateToleranceMinutes,
+		IsActive:               true,
+		CreatedAt:              now,
+		UpdatedAt:              now,
+	}
+}
+
+// ApplyUpdateRequest applies UpdateServiceOfferingRequest to an existing ServiceOffering
+func (o *ServiceOffering) ApplyUpdateRequest(req *UpdateServiceOfferingRequest) {
+	o.Name = req.Name
+	o.Description = req.Description
+	o.AverageDurationMinutes = req.AverageDurationMinutes
+	o.PriceCents = req.PriceCents
+	o.CheckInRadiusMeters = req.CheckInRadiusMeters
+	o.LateToleranceMinutes = req.LateToleranceMinutes
+	if req.IsActive != nil {
+		o.IsActive = *req.IsActive
+	}
+	o.UpdatedAt = time.Now()
+}
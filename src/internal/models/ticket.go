@@ -0,0 +1,318 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TicketStatus represents the lifecycle state of a ticket
+type TicketStatus string
+
+const (
+	TicketStatusWaiting   TicketStatus = "waiting"
+	TicketStatusCalled    TicketStatus = "called"
+	TicketStatusSkipped   TicketStatus = "skipped"
+	TicketStatusInService TicketStatus = "in_service"
+	TicketStatusDone      TicketStatus = "done"
+	TicketStatusCancelled TicketStatus = "cancelled"
+	TicketStatusNoShow    TicketStatus = "no_show"
+)
+
+// TicketCheckInStatus represents the result of a customer geolocation check-in
+type TicketCheckInStatus string
+
+const (
+	TicketCheckInAccepted TicketCheckInStatus = "accepted"
+	TicketCheckInRejected TicketCheckInStatus = "rejected"
+)
+
+// ticketTransitions lists the statuses a ticket may move to from each status
+var ticketTransitions = map[TicketStatus][]TicketStatus{
+	TicketStatusWaiting:   {TicketStatusCalled, TicketStatusCancelled},
+	TicketStatusCalled:    {TicketStatusInService, TicketStatusSkipped, TicketStatusCancelled, TicketStatusNoShow},
+	TicketStatusSkipped:   {TicketStatusCalled, TicketStatusCancelled, TicketStatusNoShow},
+	TicketStatusInService: {TicketStatusDone},
+}
+
+// CanTransitionTo checks if a ticket in this status may move to the next status
+func (s TicketStatus) CanTransitionTo(next TicketStatus) bool {
+	for _, allowed := range ticketTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveTicketStatuses lists the statuses in which a ticket still holds a place in its queue
+var ActiveTicketStatuses = []TicketStatus{
+	TicketStatusWaiting,
+	TicketStatusCalled,
+	TicketStatusSkipped,
+	TicketStatusInService,
+}
+
+// IsActive checks if the ticket still holds a place in the queue
+func (s TicketStatus) IsActive() bool {
+	for _, active := range ActiveTicketStatuses {
+		if s == active {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal checks if the status ends the ticket lifecycle
+func (s TicketStatus) IsFinal() bool {
+	return len(ticketTransitions[s]) == 0
+}
+
+// Ticket represents a customer's place in a queue
+type Ticket struct {
+	ID                    uuid.UUID            `json:"id"`
+	QueueID               uuid.UUID            `json:"queue_id"`
+	BusinessID            uuid.UUID            `json:"business_id"`
+	CustomerID            uuid.UUID            `json:"customer_id"`
+	Number                int                  `json:"number"`
+	Status                TicketStatus         `json:"status"`
+	ScheduledAt           *time.Time           `json:"scheduled_at,omitempty"` // Set for appointments only
+	ScheduledUntil        *time.Time           `json:"scheduled_until,omitempty"`
+	CalledAt              *time.Time           `json:"called_at,omitempty"`
+	StartedAt             *time.Time           `json:"started_at,omitempty"`
+	FinishedAt            *time.Time           `json:"finished_at,omitempty"`
+	LateCancellation      bool                 `json:"late_cancellation,omitempty"`
+	CheckInStatus         *TicketCheckInStatus `json:"check_in_status,omitempty"`
+	CheckInDistanceMeters *float64             `json:"check_in_distance_meters,omitempty"`
+	CheckedInAt           *time.Time           `json:"checked_in_at,omitempty"`
+	CreatedAt             time.Time            `json:"created_at"`
+	UpdatedAt             time.Time            `json:"updated_at"`
+}
+
+// TicketResponse represents the response with ticket data
+type TicketResponse struct {
+	ID                    uuid.UUID            `json:"id"`
+	QueueID               uuid.UUID            `json:"queue_id"`
+	BusinessID            uuid.UUID            `json:"business_id"`
+	CustomerID            uuid.UUID            `json:"customer_id"`
+	Number                int                  `json:"number"`
+	Status                TicketStatus         `json:"status"`
+	ScheduledAt           *time.Time           `json:"scheduled_at,omitempty"` // Set for appointments only
+	ScheduledUntil        *time.Time           `json:"scheduled_until,omitempty"`
+	CalledAt              *time.Time           `json:"called_at,omitempty"`
+	StartedAt             *time.Time           `json:"started_at,omitempty"`
+	FinishedAt            *time.Time           `json:"finished_at,omitempty"`
+	LateCancellation      bool                 `json:"late_cancellation,omitempty"`
+	CheckInStatus         *TicketCheckInStatus `json:"check_in_status,omitempty"`
+	CheckInDistanceMeters *float64             `json:"check_in_distance_meters,omitempty"`
+	CheckedInAt           *time.Time           `json:"checked_in_at,omitempty"`
+	CustomerReputation    *int                 `json:"customer_reputation,omitempty"` // Only filled in for the business owner
+	CreatedAt             time.Time            `json:"created_at"`
+	UpdatedAt             time.Time            `json:"updated_at"`
+}
+
+// ToResponse converts a Ticket to TicketResponse
+func (t *Ticket) ToResponse() *TicketResponse {
+	return &TicketResponse{
+		ID:                    t.ID,
+		QueueID:               t.QueueID,
+		BusinessID:            t.BusinessID,
+		CustomerID:            t.CustomerID,
+		Number:                t.Number,
+		Status:                t.Status,
+		ScheduledAt:           t.ScheduledAt,
+		ScheduledUntil:        t.ScheduledUntil,
+		CalledAt:              t.CalledAt,
+		StartedAt:             t.StartedAt,
+		FinishedAt:            t.FinishedAt,
+		LateCancellation:      t.LateCancellation,
+		CheckInStatus:         t.CheckInStatus,
+		CheckInDistanceMeters: t.CheckInDistanceMeters,
+		CheckedInAt:           t.CheckedInAt,
+		CreatedAt:             t.CreatedAt,
+		UpdatedAt:             t.UpdatedAt,
+	}
+}
+
+// TransitionTo moves the ticket to the next status, stamping the matching lifecycle time
+func (t *Ticket) TransitionTo(next TicketStatus, at time.Time) error {
+	if !t.Status.CanTransitionTo(next) {
+		return fmt.Errorf("invalid ticket status transition")
+	}
+
+	switch next {
+	case TicketStatusCalled:
+		t.CalledAt = &at
+	case TicketStatusInService:
+		t.StartedAt = &at
+	}
+	if next.IsFinal() {
+		t.FinishedAt = &at
+	}
+
+	t.Status = next
+	t.UpdatedAt = at
+	return nil
+}
+
+// IsNoShowAt checks if the customer failed to show up in time and the ticket should become a no-show.
+// A called ticket is overdue tolerance after it was called, unless the customer checked in.
+// A skipped ticket is overdue tolerance after it was skipped or last checked in, so it does not
+// hold a place in the queue forever when the business never recalls it.
+func (t *Ticket) IsNoShowAt(at time.Time, tolerance time.Duration) bool {
+	switch t.Status {
+	case TicketStatusCalled:
+		if t.CheckInStatus != nil && *t.CheckInStatus == TicketCheckInAccepted {
+			return false
+		}
+		return t.CalledAt != nil && t.CalledAt.Add(tolerance).Before(at)
+	case TicketStatusSkipped:
+		return t.UpdatedAt.Add(tolerance).Before(at)
+	}
+	return false
+}
+
+// Cancel moves the ticket to cancelled, recording whether it broke the cancellation policy
+func (t *Ticket) Cancel(at time.Time, late bool) error {
+	if err := t.TransitionTo(TicketStatusCancelled, at); err != nil {
+		return err
+	}
+	t.LateCancellation = late
+	return nil
+}
+
+// Reschedule moves the ticket to a new time slot, turning a walk-in ticket into an appointment
+func (t *Ticket) Reschedule(slot TimeSlot, at time.Time) {
+	t.ScheduledAt = &slot.StartAt
+	t.ScheduledUntil = &slot.EndAt
+	t.UpdatedAt = at
+}
+
+// IsAppointment checks if the ticket was booked for a time slot rather than issued on walk-in
+func (t *Ticket) IsAppointment() bool {
+	return t.ScheduledAt != nil
+}
+
+// RecordCheckIn stores the result of a customer check-in attempt
+func (t *Ticket) RecordCheckIn(status TicketCheckInStatus, distanceMeters float64, at time.Time) {
+	t.CheckInStatus = &status
+	t.CheckInDistanceMeters = &distanceMeters
+	t.CheckedInAt = &at
+	t.UpdatedAt = at
+}
+
+// CheckInRequest represents the device location sent by a customer checking in
+type CheckInRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required,min=-180,max=180"`
+}
+
+// CheckInResponse represents the result of a customer check-in
+type CheckInResponse struct {
+	Ticket         *TicketResponse     `json:"ticket"`
+	Status         TicketCheckInStatus `json:"status"`
+	DistanceMeters float64             `json:"distance_meters"`
+	RadiusMeters   int                 `json:"radius_meters"` // 0 means the business does not restrict the distance
+}
+
+// TicketPositionResponse represents a customer's current place in a queue
+type TicketPositionResponse struct {
+	Ticket               *TicketResponse `json:"ticket"`
+	Position             int             `json:"position"` // 1 means next to be called; 0 once the ticket has been called
+	TicketsAhead         int             `json:"tickets_ahead"`
+	EstimatedWaitMinutes int             `json:"estimated_wait_minutes"`
+	EstimatedStartAt     time.Time       `json:"estimated_start_at"`
+}
+
+// NewTicket creates a waiting ticket for a customer in a queue
+func NewTicket(queue *Queue, customerID uuid.UUID) *Ticket {
+	now := time.Now()
+	return &Ticket{
+		ID:         uuid.New(),
+		QueueID:    queue.ID,
+		BusinessID: queue.BusinessID,
+		CustomerID: customerID,
+		Status:     TicketStatusWaiting,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// NewAppointmentTicket creates a waiting ticket booked for a time slot in a queue
+func NewAppointmentTicket(queue *Queue, customerID uuid.UUID, slot TimeSlot) *Ticket {
+	ticket := NewTicket(queue, customerID)
+	ticket.ScheduledAt = &slot.StartAt
+	ticket.ScheduledUntil = &slot.EndAt
+	return ticket
+}
+
+// BookAppointmentRequest represents the request to book a time slot in a queue
+type BookAppointmentRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" binding:"required"`
+}
+
+// RescheduleTicketRequest represents the request to move a ticket to another time slot
+type RescheduleTicketRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" binding:"required"`
+}
+
+// AvailabilityResponse represents the free appointment slots of a queue on a day
+type AvailabilityResponse struct {
+	QueueID         uuid.UUID  `json:"queue_id"`
+	Date            string     `json:"date"`
+	Timezone        string     `json:"timezone"`
+	DurationMinutes int        `json:"duration_minutes"`
+	Slots           []TimeSlot `json:"slots"`
+}
+
+// QueueEstimate is the expected place and start time of a ticket
+type QueueEstimate struct {
+	Position int
+	Ahead    int
+	StartAt  time.Time
+}
+
+// EstimateStart simulates how the waiting tickets of a queue will be served, one every
+// serviceTime starting at now, to find when the target ticket is expected to start.
+// Walk-in tickets are served in number order, but an appointment goes first once its
+// slot has started; the queue idles until the next appointment when nothing else is waiting.
+// waiting must be ordered by number. It returns false if the target is not waiting.
+func EstimateStart(waiting []*Ticket, targetID uuid.UUID, now time.Time, serviceTime time.Duration) (QueueEstimate, bool) {
+	var walkIns, appointments []*Ticket
+	for _, ticket := range waiting {
+		if ticket.IsAppointment() {
+			appointments = append(appointments, ticket)
+		} else {
+			walkIns = append(walkIns, ticket)
+		}
+	}
+	sort.SliceStable(appointments, func(i, j int) bool {
+		return appointments[i].ScheduledAt.Before(*appointments[j].ScheduledAt)
+	})
+
+	at := now
+	served := 0
+	for len(walkIns) > 0 || len(appointments) > 0 {
+		var next *Ticket
+		switch {
+		case len(appointments) > 0 && !appointments[0].ScheduledAt.After(at):
+			next, appointments = appointments[0], appointments[1:]
+		case len(walkIns) > 0:
+			next, walkIns = walkIns[0], walkIns[1:]
+		default:
+			at = *appointments[0].ScheduledAt
+			continue
+		}
+
+		if next.ID == targetID {
+			return QueueEstimate{Position: served + 1, Ahead: served, StartAt: at}, true
+		}
+
+		served++
+		at = at.Add(serviceTime)
+	}
+
+	return QueueEstimate{}, false
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicketIsNoShowAt(t *testing.T) {
+	tolerance := 10 * time.Minute
+	calledAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	accepted := TicketCheckInAccepted
+	rejected := TicketCheckInRejected
+
+	tests := []struct {
+		name   string
+		ticket Ticket
+		at     time.Time
+		want   bool
+	}{
+		{
+			name:   "called within tolerance",
+			ticket: Ticket{Status: TicketStatusCalled, CalledAt: &calledAt, UpdatedAt: calledAt},
+			at:     calledAt.Add(5 * time.Minute),
+			want:   false,
+		},
+		{
+			name:   "called past tolerance",
+			ticket: Ticket{Status: TicketStatusCalled, CalledAt: &calledAt, UpdatedAt: calledAt},
+			at:     calledAt.Add(11 * time.Minute),
+			want:   true,
+		},
+		{
+			name:   "called past tolerance after a rejected check-in",
+			ticket: Ticket{Status: TicketStatusCalled, CalledAt: &calledAt, CheckInStatus: &rejected, UpdatedAt: calledAt},
+			at:     calledAt.Add(11 * time.Minute),
+			want:   true,
+		},
+		{
+			name:   "called and checked in",
+			ticket: Ticket{Status: TicketStatusCalled, CalledAt: &calledAt, CheckInStatus: &accepted, UpdatedAt: calledAt},
+			at:     calledAt.Add(time.Hour),
+			want:   false,
+		},
+		{
+			name:   "skipped within tolerance",
+			ticket: Ticket{Status: TicketStatusSkipped, CalledAt: &calledAt, UpdatedAt: calledAt.Add(20 * time.Minute)},
+			at:     calledAt.Add(25 * time.Minute),
+			want:   false,
+		},
+		{
+			name:   "skipped past tolerance",
+			ticket: Ticket{Status: TicketStatusSkipped, CalledAt: &calledAt, UpdatedAt: calledAt.Add(20 * time.Minute)},
+			at:     calledAt.Add(31 * time.Minute),
+			want:   true,
+		},
+		{
+			name:   "skipped past tolerance even if checked in",
+			ticket: Ticket{Status: TicketStatusSkipped, CalledAt: &calledAt, CheckInStatus: &accepted, UpdatedAt: calledAt},
+			at:     calledAt.Add(11 * time.Minute),
+			want:   true,
+		},
+		{
+			name:   "waiting ticket",
+			ticket: Ticket{Status: TicketStatusWaiting, UpdatedAt: calledAt},
+			at:     calledAt.Add(time.Hour),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ticket.IsNoShowAt(tt.at, tolerance); got != tt.want {
+				t.Errorf("IsNoShowAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
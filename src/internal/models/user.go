@@ -15,35 +15,74 @@ const (
 	RoleAdmin         UserRole = "AD"
 )
 
+// IsValid checks if the role is one of the known user roles
+func (r UserRole) IsValid() bool {
+	switch r {
+	case RoleBusinessOwner, RoleCustomer, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 // User represents a user in the system
 type User struct {
-	ID           uuid.UUID  `json:"id"`
-	Email        string     `json:"email"`
-	PasswordHash string     `json:"-"` // Do not expose in JSON
-	Phone        string     `json:"phone"`
-	Roles        []UserRole `json:"roles"`
-	IsActive     bool       `json:"is_active"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	Email            string     `json:"email"`
+	PasswordHash     string     `json:"-"` // Do not expose in JSON
+	Phone            string     `json:"phone"`
+	Roles            []UserRole `json:"roles"`
+	IsActive         bool       `json:"is_active"`
+	Reputation       Reputation `json:"reputation"`
+	PhoneVerifiedAt  *time.Time `json:"phone_verified_at,omitempty"`
+	TokensValidAfter *time.Time `json:"-"` // Tokens issued earlier are rejected
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
-// CreateUserRequest represents the request to create a user
+// CreateUserRequest represents the request to register a user.
+// The admin role cannot be requested at registration; see AdminCreateUserRequest.
 type CreateUserRequest struct {
+	Email    string     `json:"email" binding:"required,email"`
+	Password string     `json:"password" binding:"required,min=6"`
+	Phone    string     `json:"phone" binding:"required"`
+	Roles    []UserRole `json:"roles" binding:"required,min=1,dive,oneof=BO CU"`
+}
+
+// AdminCreateUserRequest represents the request of an admin to create a user with any roles
+type AdminCreateUserRequest struct {
 	Email    string     `json:"email" binding:"required,email"`
 	Password string     `json:"password" binding:"required,min=6"`
 	Phone    string     `json:"phone" binding:"required"`
 	Roles    []UserRole `json:"roles" binding:"required,min=1,dive,oneof=BO CU AD"`
 }
 
+// CreateUserRequest converts the request to the CreateUserRequest it extends
+func (req *AdminCreateUserRequest) CreateUserRequest() *CreateUserRequest {
+	return &CreateUserRequest{
+		Email:    req.Email,
+		Password: req.Password,
+		Phone:    req.Phone,
+		Roles:    req.Roles,
+	}
+}
+
+// UpdateProfileRequest represents the request of a user to update their own profile.
+// Omitted fields are left unchanged.
+type UpdateProfileRequest struct {
+	Phone *string `json:"phone" binding:"omitempty,min=1"`
+}
+
 // UserResponse represents the response with user data
 type UserResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	Email     string     `json:"email"`
-	Phone     string     `json:"phone"`
-	Roles     []UserRole `json:"roles"`
-	IsActive  bool       `json:"is_active"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID              uuid.UUID  `json:"id"`
+	Email           string     `json:"email"`
+	Phone           string     `json:"phone"`
+	Roles           []UserRole `json:"roles"`
+	IsActive        bool       `json:"is_active"`
+	Reputation      Reputation `json:"reputation"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // HasRole checks if the user has a specific role
@@ -56,16 +95,52 @@ func (u *User) HasRole(role UserRole) bool {
 	return false
 }
 
+// AddRole grants a role to the user, reporting whether the user did not have it yet
+func (u *User) AddRole(role UserRole) bool {
+	if u.HasRole(role) {
+		return false
+	}
+	u.Roles = append(u.Roles, role)
+	return true
+}
+
+// RemoveRole takes a role away from the user, reporting whether the user had it
+func (u *User) RemoveRole(role UserRole) bool {
+	for i, r := range u.Roles {
+		if r == role {
+			u.Roles = append(u.Roles[:i:i], u.Roles[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// IsPhoneVerified checks if the user's current phone number has been verified
+func (u *User) IsPhoneVerified() bool {
+	return u.PhoneVerifiedAt != nil
+}
+
+// AcceptsTokenIssuedAt checks if a token issued at the given time is still valid for the user.
+// JWT issue times have second precision, so the cutoff is compared at the same precision.
+func (u *User) AcceptsTokenIssuedAt(issuedAt time.Time) bool {
+	if u.TokensValidAfter == nil {
+		return true
+	}
+	return !issuedAt.Before(u.TokensValidAfter.Truncate(time.Second))
+}
+
 // ToResponse converts a User to UserResponse
 func (u *User) ToResponse() *UserResponse {
 	return &UserResponse{
-		ID:        u.ID,
-		Email:     u.Email,
-		Phone:     u.Phone,
-		Roles:     u.Roles,
-		IsActive:  u.IsActive,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:              u.ID,
+		Email:           u.Email,
+		Phone:           u.Phone,
+		Roles:           u.Roles,
+		IsActive:        u.IsActive,
+		Reputation:      u.Reputation,
+		PhoneVerifiedAt: u.PhoneVerifiedAt,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
 	}
 }
 
@@ -79,6 +154,7 @@ func (req *CreateUserRequest) ToUser(passwordHash string) *User {
 		Phone:        req.Phone,
 		Roles:        req.Roles,
 		IsActive:     true,
+		Reputation:   Reputation{Score: MaxReputationScore},
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
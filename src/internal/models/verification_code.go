@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VerificationPurpose identifies what a verification code proves
+type VerificationPurpose string
+
+const (
+	VerificationPurposePasswordReset VerificationPurpose = "password_reset"
+	VerificationPurposePhone         VerificationPurpose = "phone_verification"
+)
+
+// VerificationCode is a short-lived single-use code sent to a user. Only its hash is stored.
+type VerificationCode struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Purpose    VerificationPurpose
+	Phone      string
+	CodeHash   string
+	ExpiresAt  time.Time
+	Attempts   int
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// NewVerificationCode creates a verification code record for a user from the hash of the code
+// and the phone number it is sent to
+func NewVerificationCode(userID uuid.UUID, purpose VerificationPurpose, phone, codeHash string, ttl time.Duration) *VerificationCode {
+	now := time.Now()
+	return &VerificationCode{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		Phone:     phone,
+		CodeHash:  codeHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// VerifyPhoneRequest represents the request to verify the user's phone number with a code
+type VerifyPhoneRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// RequestPasswordResetRequest represents the request to send a password reset code
+type RequestPasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ConfirmPasswordResetRequest represents the request to set a new password with a reset code
+type ConfirmPasswordResetRequest struct {
+	Email       string `json:"email" binding:"required,email"`
+	Code        string `json:"code" binding:"required,len=6,numeric"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
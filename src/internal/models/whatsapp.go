@@ -113,13 +113,35 @@ type WhatsAppWebhookPayload struct {
 					Text      struct {
 						Body string `json:"body"`
 					} `json:"text"`
+					Location struct {
+						Latitude  float64 `json:"latitude"`
+						Longitude float64 `json:"longitude"`
+					} `json:"location"`
 				} `json:"messages"`
+				Statuses []struct {
+					ID          string `json:"id"`
+					Status      string `json:"status"`
+					Timestamp   string `json:"timestamp"`
+					RecipientID string `json:"recipient_id"`
+					Errors      []struct {
+						Code  int    `json:"code"`
+						Title string `json:"title"`
+					} `json:"errors"`
+				} `json:"statuses"`
 			} `json:"value"`
 			Field string `json:"field"`
 		} `json:"changes"`
 	} `json:"entry"`
 }
 
+// WhatsAppInboundMessage represents a text or location message a user sent to the business number
+type WhatsAppInboundMessage struct {
+	From      string
+	Text      string
+	Latitude  *float64 // Set for location messages only
+	Longitude *float64
+}
+
 // WhatsAppWebhookVerification represents the webhook verification request
 type WhatsAppWebhookVerification struct {
 	Mode      string `form:"hub.mode"`
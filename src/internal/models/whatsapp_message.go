@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WhatsAppMessageStatus represents the delivery status of an outbound WhatsApp message
+type WhatsAppMessageStatus string
+
+const (
+	WhatsAppMessageStatusAccepted  WhatsAppMessageStatus = "accepted"  // Accepted by the Graph API, no status received yet
+	WhatsAppMessageStatusSent      WhatsAppMessageStatus = "sent"      // Sent by WhatsApp
+	WhatsAppMessageStatusDelivered WhatsAppMessageStatus = "delivered" // Delivered to the recipient's device
+	WhatsAppMessageStatusRead      WhatsAppMessageStatus = "read"      // Read by the recipient
+	WhatsAppMessageStatusFailed    WhatsAppMessageStatus = "failed"    // Could not be delivered
+)
+
+// IsValid checks if the status is one Meta reports in status callbacks
+func (s WhatsAppMessageStatus) IsValid() bool {
+	switch s {
+	case WhatsAppMessageStatusSent, WhatsAppMessageStatusDelivered, WhatsAppMessageStatusRead, WhatsAppMessageStatusFailed:
+		return true
+	}
+	return false
+}
+
+// WhatsAppMessage is the log entry of an outbound WhatsApp message, keyed by the message ID
+// returned by the Graph API. Messages about a ticket are linked to it and to its business.
+type WhatsAppMessage struct {
+	ID           string                `json:"id"`
+	Recipient    string                `json:"recipient"`
+	BusinessID   *uuid.UUID            `json:"business_id,omitempty"`
+	TicketID     *uuid.UUID            `json:"ticket_id,omitempty"`
+	Type         *WhatsAppMessageType  `json:"type,omitempty"`
+	TemplateName *string               `json:"template_name,omitempty"`
+	Status       WhatsAppMessageStatus `json:"status"`
+	ErrorCode    *int                  `json:"error_code,omitempty"`
+	ErrorTitle   *string               `json:"error_title,omitempty"`
+	SentAt       *time.Time            `json:"sent_at,omitempty"`
+	DeliveredAt  *time.Time            `json:"delivered_at,omitempty"`
+	ReadAt       *time.Time            `json:"read_at,omitempty"`
+	FailedAt     *time.Time            `json:"failed_at,omitempty"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
+
+// NewWhatsAppMessage creates the log entry of a message just accepted by the Graph API
+func NewWhatsAppMessage(id, recipient string, messageType WhatsAppMessageType, templateName string) *WhatsAppMessage {
+	now := time.Now()
+	message := &WhatsAppMessage{
+		ID:        id,
+		Recipient: recipient,
+		Type:      &messageType,
+		Status:    WhatsAppMessageStatusAccepted,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if templateName != "" {
+		message.TemplateName = &templateName
+	}
+	return message
+}
+
+// WhatsAppStatusUpdate is a delivery status reported by Meta for an outbound message
+type WhatsAppStatusUpdate struct {
+	MessageID  string
+	Recipient  string
+	Status     WhatsAppMessageStatus
+	Timestamp  time.Time
+	ErrorCode  *int
+	ErrorTitle *string
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// WhatsAppAccessToken is the latest access token obtained by extending the configured one,
+// stored so a restart does not fall back to the configured token once it expired
+type WhatsAppAccessToken struct {
+	PhoneNumberID string
+	AccessToken   string
+	SeedTokenHash string // SHA-256 of the configured token the access token was extended from
+	ExpiresAt     *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// WhatsAppTokenStatus describes the access token used to call the Graph API
+type WhatsAppTokenStatus struct {
+	Managed         bool       `json:"managed"` // The token is validated and extended automatically
+	Source          string     `json:"source"`  // config or stored
+	Valid           bool       `json:"valid"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	TimeUntilExpiry string     `json:"time_until_expiry,omitempty"`
+	LastRefreshedAt *time.Time `json:"last_refreshed_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BusinessClosureRepository defines the interface for business closure operations
+type BusinessClosureRepository interface {
+	Create(ctx context.Context, closure *models.BusinessClosure) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.BusinessClosure, error)
+	FindByBusinessID(ctx context.Context, businessID uuid.UUID, endsAfter time.Time) ([]*models.BusinessClosure, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// businessClosureRepository implements BusinessClosureRepository
+type businessClosureRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBusinessClosureRepository creates a new instance of BusinessClosureRepository
+func NewBusinessClosureRepository(pool *pgxpool.Pool) BusinessClosureRepository {
+	return &businessClosureRepository{
+		pool: pool,
+	}
+}
+
+const businessClosureColumns = `id, business_id, starts_at, ends_at, COALESCE(reason, ''), created_at`
+
+// scanBusinessClosure scans a single business closure row
+func scanBusinessClosure(row pgx.Row) (*models.BusinessClosure, error) {
+	closure := &models.BusinessClosure{}
+	err := row.Scan(
+		&closure.ID,
+		&closure.BusinessID,
+		&closure.StartsAt,
+		&closure.EndsAt,
+		&closure.Reason,
+		&closure.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return closure, nil
+}
+
+// Create inserts a new business closure into the database
+func (r *businessClosureRepository) Create(ctx context.Context, closure *models.BusinessClosure) error {
+	query := `
+		INSERT INTO business_closures (id, business_id, starts_at, ends_at, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		closure.ID,
+		closure.BusinessID,
+		closure.StartsAt,
+		closure.EndsAt,
+		closure.Reason,
+		closure.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create business closure: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a business closure by ID
+func (r *businessClosureRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.BusinessClosure, error) {
+	query := `SELECT ` + businessClosureColumns + ` FROM business_closures WHERE id = $1`
+
+	closure, err := scanBusinessClosure(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("business closure not found")
+		}
+		return nil, fmt.Errorf("failed to find business closure: %w", err)
+	}
+
+	return closure, nil
+}
+
+// FindByBusinessID retrieves the closures of a business that end after the given time, in chronological order
+func (r *businessClosureRepository) FindByBusinessID(ctx context.Context, businessID uuid.UUID, endsAfter time.Time) ([]*models.BusinessClosure, error) {
+	query := `
+		SELECT ` + businessClosureColumns + `
+		FROM business_closures
+		WHERE business_id = $1 AND ends_at > $2
+		ORDER BY starts_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, businessID, endsAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query business closures: %w", err)
+	}
+	defer rows.Close()
+
+	var closures []*models.BusinessClosure
+	for rows.Next() {
+		closure, err := scanBusinessClosure(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan business closure: %w", err)
+		}
+		closures = append(closures, closure)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating business closures: %w", err)
+	}
+
+	return closures, nil
+}
+
+// Delete removes a business closure from the database
+func (r *businessClosureRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM business_closures WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete business closure: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("business closure not found")
+	}
+
+	return nil
+}
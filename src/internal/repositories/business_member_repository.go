@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BusinessMemberRepository defines the interface for business member operations
+type BusinessMemberRepository interface {
+	Upsert(ctx context.Context, member *models.BusinessMember) error
+	Find(ctx context.Context, businessID, userID uuid.UUID) (*models.BusinessMember, error)
+	FindByBusinessID(ctx context.Context, businessID uuid.UUID) ([]*models.BusinessMember, error)
+	Delete(ctx context.Context, businessID, userID uuid.UUID) error
+}
+
+// businessMemberRepository implements BusinessMemberRepository
+type businessMemberRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBusinessMemberRepository creates a new instance of BusinessMemberRepository
+func NewBusinessMemberRepository(pool *pgxpool.Pool) BusinessMemberRepository {
+	return &businessMemberRepository{
+		pool: pool,
+	}
+}
+
+const businessMemberColumns = `business_id, user_id, role, created_at, updated_at`
+
+// scanBusinessMember scans a single business member row
+func scanBusinessMember(row pgx.Row) (*models.BusinessMember, error) {
+	member := &models.BusinessMember{}
+	err := row.Scan(
+		&member.BusinessID,
+		&member.UserID,
+		&member.Role,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// Upsert adds a member to a business, or changes the role of an existing member
+func (r *businessMemberRepository) Upsert(ctx context.Context, member *models.BusinessMember) error {
+	query := `
+		INSERT INTO business_members (business_id, user_id, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (business_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		member.BusinessID,
+		member.UserID,
+		member.Role,
+		member.CreatedAt,
+		member.UpdatedAt,
+	).Scan(&member.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to save business member: %w", err)
+	}
+
+	return nil
+}
+
+// Find retrieves the membership of a user in a business
+func (r *businessMemberRepository) Find(ctx context.Context, businessID, userID uuid.UUID) (*models.BusinessMember, error) {
+	query := `SELECT ` + businessMemberColumns + ` FROM business_members WHERE business_id = $1 AND user_id = $2`
+
+	member, err := scanBusinessMember(r.pool.QueryRow(ctx, query, businessID, userID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("business member not found")
+		}
+		return nil, fmt.Errorf("failed to find business member: %w", err)
+	}
+
+	return member, nil
+}
+
+// FindByBusinessID retrieves the members of a business, oldest first
+func (r *businessMemberRepository) FindByBusinessID(ctx context.Context, businessID uuid.UUID) ([]*models.BusinessMember, error) {
+	query := `
+		SELECT ` + businessMemberColumns + `
+		FROM business_members
+		WHERE business_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query business members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*models.BusinessMember
+	for rows.Next() {
+		member, err := scanBusinessMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan business member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating business members: %w", err)
+	}
+
+	return members, nil
+}
+
+// Delete removes a member from a business
+func (r *businessMemberRepository) Delete(ctx context.Context, businessID, userID uuid.UUID) error {
+	query := `DELETE FROM business_members WHERE business_id = $1 AND user_id = $2`
+
+	result, err := r.pool.Exec(ctx, query, businessID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete business member: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("business member not found")
+	}
+
+	return nil
+}
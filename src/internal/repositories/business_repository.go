@@ -17,6 +17,7 @@ type BusinessRepository interface {
 	FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Business, error)
 	FindAll(ctx context.Context) ([]*models.Business, error)
 	Update(ctx context.Context, business *models.Business) error
+	UpdateCancellationPolicy(ctx context.Context, businessID uuid.UUID, noticeMinutes int) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -35,8 +36,8 @@ func NewBusinessRepository(pool *pgxpool.Pool) BusinessRepository {
 // Create inserts a new business into the database
 func (r *businessRepository) Create(ctx context.Context, business *models.Business) error {
 	query := `
-		INSERT INTO businesses (id, owner_id, name, description, address, phone, email, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO businesses (id, owner_id, name, description, address, latitude, longitude, timezone, phone, email, is_active, require_verified_phone, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
@@ -45,9 +46,13 @@ func (r *businessRepository) Create(ctx context.Context, business *models.Busine
 		business.Name,
 		business.Description,
 		business.Address,
+		business.Latitude,
+		business.Longitude,
+		business.Timezone,
 		business.Phone,
 		business.Email,
 		business.IsActive,
+		business.RequireVerifiedPhone,
 		business.CreatedAt,
 		business.UpdatedAt,
 	)
@@ -59,68 +64,41 @@ func (r *businessRepository) Create(ctx context.Context, business *models.Busine
 	return nil
 }
 
-// FindByID retrieves a business by ID
-func (r *businessRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Business, error) {
-	query := `
-		SELECT id, owner_id, name, description, address, phone, email, is_active, created_at, updated_at
-		FROM businesses
-		WHERE id = $1
-	`
+const businessColumns = `id, owner_id, name, description, address, latitude, longitude, timezone, phone, email, is_active, cancellation_notice_minutes, require_verified_phone, created_at, updated_at`
 
+// scanBusiness scans a single business row
+func scanBusiness(row pgx.Row) (*models.Business, error) {
 	business := &models.Business{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := row.Scan(
 		&business.ID,
 		&business.OwnerID,
 		&business.Name,
 		&business.Description,
 		&business.Address,
+		&business.Latitude,
+		&business.Longitude,
+		&business.Timezone,
 		&business.Phone,
 		&business.Email,
 		&business.IsActive,
+		&business.CancellationNoticeMinutes,
+		&business.RequireVerifiedPhone,
 		&business.CreatedAt,
 		&business.UpdatedAt,
 	)
-
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("business not found")
-		}
-		return nil, fmt.Errorf("failed to find business: %w", err)
+		return nil, err
 	}
-
 	return business, nil
 }
 
-// FindByOwnerID retrieves all businesses owned by a specific user
-func (r *businessRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Business, error) {
-	query := `
-		SELECT id, owner_id, name, description, address, phone, email, is_active, created_at, updated_at
-		FROM businesses
-		WHERE owner_id = $1
-		ORDER BY created_at DESC
-	`
-
-	rows, err := r.pool.Query(ctx, query, ownerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query businesses: %w", err)
-	}
+// scanBusinesses scans all business rows, closing them when done
+func scanBusinesses(rows pgx.Rows) ([]*models.Business, error) {
 	defer rows.Close()
 
 	var businesses []*models.Business
 	for rows.Next() {
-		business := &models.Business{}
-		err := rows.Scan(
-			&business.ID,
-			&business.OwnerID,
-			&business.Name,
-			&business.Description,
-			&business.Address,
-			&business.Phone,
-			&business.Email,
-			&business.IsActive,
-			&business.CreatedAt,
-			&business.UpdatedAt,
-		)
+		business, err := scanBusiness(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan business: %w", err)
 		}
@@ -134,53 +112,50 @@ func (r *businessRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUI
 	return businesses, nil
 }
 
-// FindAll returns all businesses
-func (r *businessRepository) FindAll(ctx context.Context) ([]*models.Business, error) {
-	query := `
-		SELECT id, owner_id, name, description, address, phone, email, is_active, created_at, updated_at
-		FROM businesses
-		ORDER BY created_at DESC
-	`
+// FindByID retrieves a business by ID
+func (r *businessRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Business, error) {
+	query := `SELECT ` + businessColumns + ` FROM businesses WHERE id = $1`
 
-	rows, err := r.pool.Query(ctx, query)
+	business, err := scanBusiness(r.pool.QueryRow(ctx, query, id))
 	if err != nil {
-		return nil, fmt.Errorf("failed to query businesses: %w", err)
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("business not found")
+		}
+		return nil, fmt.Errorf("failed to find business: %w", err)
 	}
-	defer rows.Close()
 
-	var businesses []*models.Business
-	for rows.Next() {
-		business := &models.Business{}
-		err := rows.Scan(
-			&business.ID,
-			&business.OwnerID,
-			&business.Name,
-			&business.Description,
-			&business.Address,
-			&business.Phone,
-			&business.Email,
-			&business.IsActive,
-			&business.CreatedAt,
-			&business.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan business: %w", err)
-		}
-		businesses = append(businesses, business)
+	return business, nil
+}
+
+// FindByOwnerID retrieves all businesses owned by a specific user
+func (r *businessRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Business, error) {
+	query := `SELECT ` + businessColumns + ` FROM businesses WHERE owner_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query businesses: %w", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating businesses: %w", err)
+	return scanBusinesses(rows)
+}
+
+// FindAll returns all businesses
+func (r *businessRepository) FindAll(ctx context.Context) ([]*models.Business, error) {
+	query := `SELECT ` + businessColumns + ` FROM businesses ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query businesses: %w", err)
 	}
 
-	return businesses, nil
+	return scanBusinesses(rows)
 }
 
 // Update updates an existing business
 func (r *businessRepository) Update(ctx context.Context, business *models.Business) error {
 	query := `
 		UPDATE businesses
-		SET name = $2, description = $3, address = $4, phone = $5, email = $6, is_active = $7, updated_at = $8
+		SET name = $2, description = $3, address = $4, latitude = $5, longitude = $6, phone = $7, email = $8, is_active = $9, require_verified_phone = $10, updated_at = $11
 		WHERE id = $1
 	`
 
@@ -189,9 +164,12 @@ func (r *businessRepository) Update(ctx context.Context, business *models.Busine
 		business.Name,
 		business.Description,
 		business.Address,
+		business.Latitude,
+		business.Longitude,
 		business.Phone,
 		business.Email,
 		business.IsActive,
+		business.RequireVerifiedPhone,
 		business.UpdatedAt,
 	)
 
@@ -206,6 +184,26 @@ func (r *businessRepository) Update(ctx context.Context, business *models.Busine
 	return nil
 }
 
+// UpdateCancellationPolicy sets how long before an appointment customers may cancel it for free
+func (r *businessRepository) UpdateCancellationPolicy(ctx context.Context, businessID uuid.UUID, noticeMinutes int) error {
+	query := `
+		UPDATE businesses
+		SET cancellation_notice_minutes = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, businessID, noticeMinutes)
+	if err != nil {
+		return fmt.Errorf("failed to update cancellation policy: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("business not found")
+	}
+
+	return nil
+}
+
 // Delete removes a business from the database
 func (r *businessRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM businesses WHERE id = $1`
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// execer is implemented by both the pool and transactions,
+// so events can be written in the same transaction as the change they describe
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// insertDomainEvent writes a domain event using the given pool or transaction
+func insertDomainEvent(ctx context.Context, db execer, event *models.DomainEvent) error {
+	query := `
+		INSERT INTO domain_events (id, event_type, business_id, ticket_id, user_id, payload, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := db.Exec(ctx, query,
+		event.ID,
+		event.Type,
+		event.BusinessID,
+		event.TicketID,
+		event.UserID,
+		event.Payload,
+		event.OccurredAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to record domain event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LoginThrottleRepository defines the interface for login throttle operations
+type LoginThrottleRepository interface {
+	Find(ctx context.Context, scope models.LoginThrottleScope, key string) (*models.LoginThrottle, error)
+	ReserveAttempt(ctx context.Context, scope models.LoginThrottleScope, key string, at time.Time, window time.Duration) (*models.LoginThrottle, error)
+	Release(ctx context.Context, scope models.LoginThrottleScope, key string) error
+	Lock(ctx context.Context, scope models.LoginThrottleScope, key string, until time.Time) error
+	Reset(ctx context.Context, scope models.LoginThrottleScope, key string) error
+}
+
+// loginThrottleRepository implements LoginThrottleRepository
+type loginThrottleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLoginThrottleRepository creates a new instance of LoginThrottleRepository
+func NewLoginThrottleRepository(pool *pgxpool.Pool) LoginThrottleRepository {
+	return &loginThrottleRepository{
+		pool: pool,
+	}
+}
+
+const loginThrottleColumns = `scope, key, failed_attempts, last_failed_at, locked_until`
+
+// scanLoginThrottle scans a single login throttle row
+func scanLoginThrottle(row pgx.Row) (*models.LoginThrottle, error) {
+	throttle := &models.LoginThrottle{}
+	err := row.Scan(
+		&throttle.Scope,
+		&throttle.Key,
+		&throttle.FailedAttempts,
+		&throttle.LastFailedAt,
+		&throttle.LockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return throttle, nil
+}
+
+// Find retrieves the throttle of an email or client IP
+func (r *loginThrottleRepository) Find(ctx context.Context, scope models.LoginThrottleScope, key string) (*models.LoginThrottle, error) {
+	query := `SELECT ` + loginThrottleColumns + ` FROM login_throttles WHERE scope = $1 AND key = $2`
+
+	throttle, err := scanLoginThrottle(r.pool.QueryRow(ctx, query, scope, key))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("login throttle not found")
+		}
+		return nil, fmt.Errorf("failed to find login throttle: %w", err)
+	}
+
+	return throttle, nil
+}
+
+// ReserveAttempt counts a login attempt for the key before its password is verified and returns the updated throttle.
+// The count starts over when the previous attempt is older than the window, and is left as is while the key is locked.
+// The increment is a single statement, so concurrent attempts from several API instances each get their own count
+// and cannot all pass a check made before any of them is recorded.
+func (r *loginThrottleRepository) ReserveAttempt(ctx context.Context, scope models.LoginThrottleScope, key string, at time.Time, window time.Duration) (*models.LoginThrottle, error) {
+	query := `
+		INSERT INTO login_throttles (scope, key, failed_attempts, last_failed_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (scope, key) DO UPDATE SET
+			failed_attempts = CASE
+				WHEN login_throttles.locked_until > $3 THEN login_throttles.failed_attempts
+				WHEN login_throttles.last_failed_at < $4 THEN 1
+				ELSE login_throttles.failed_attempts + 1
+			END,
+			last_failed_at = CASE
+				WHEN login_throttles.locked_until > $3 THEN login_throttles.last_failed_at
+				ELSE EXCLUDED.last_failed_at
+			END
+		RETURNING ` + loginThrottleColumns
+
+	throttle, err := scanLoginThrottle(r.pool.QueryRow(ctx, query, scope, key, at, at.Add(-window)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve login attempt: %w", err)
+	}
+
+	return throttle, nil
+}
+
+// Release gives back an attempt reserved for the key that turned out to be successful
+func (r *loginThrottleRepository) Release(ctx context.Context, scope models.LoginThrottleScope, key string) error {
+	query := `
+		UPDATE login_throttles
+		SET failed_attempts = GREATEST(failed_attempts - 1, 0)
+		WHERE scope = $1 AND key = $2
+	`
+
+	if _, err := r.pool.Exec(ctx, query, scope, key); err != nil {
+		return fmt.Errorf("failed to release login attempt: %w", err)
+	}
+
+	return nil
+}
+
+// Lock blocks logins for the key until the given time. A lock that already lasts longer is kept.
+func (r *loginThrottleRepository) Lock(ctx context.Context, scope models.LoginThrottleScope, key string, until time.Time) error {
+	query := `
+		UPDATE login_throttles
+		SET locked_until = GREATEST(COALESCE(locked_until, $3), $3)
+		WHERE scope = $1 AND key = $2
+	`
+
+	if _, err := r.pool.Exec(ctx, query, scope, key, until); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	return nil
+}
+
+// Reset forgets the failed login attempts of the key
+func (r *loginThrottleRepository) Reset(ctx context.Context, scope models.LoginThrottleScope, key string) error {
+	query := `DELETE FROM login_throttles WHERE scope = $1 AND key = $2`
+
+	if _, err := r.pool.Exec(ctx, query, scope, key); err != nil {
+		return fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+
+	return nil
+}
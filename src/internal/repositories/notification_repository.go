@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NotificationRepository defines the interface for the outbound notification outbox
+type NotificationRepository interface {
+	Enqueue(ctx context.Context, notification *models.Notification) error
+	ClaimDue(ctx context.Context, at time.Time, limit int, lease time.Duration) ([]*models.Notification, error)
+	MarkSent(ctx context.Context, id uuid.UUID, messageID string, at time.Time) error
+	Reschedule(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error
+	MarkDead(ctx context.Context, id uuid.UUID, lastError string) error
+}
+
+// notificationRepository implements NotificationRepository
+type notificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewNotificationRepository creates a new instance of NotificationRepository
+func NewNotificationRepository(pool *pgxpool.Pool) NotificationRepository {
+	return &notificationRepository{
+		pool: pool,
+	}
+}
+
+const notificationColumns = `id, message, business_id, ticket_id, status, attempts, next_attempt_at, last_error, message_id, sent_at, created_at, updated_at`
+
+// scanNotification scans a single notification row
+func scanNotification(row pgx.Row) (*models.Notification, error) {
+	notification := &models.Notification{}
+	var message []byte
+	err := row.Scan(
+		&notification.ID,
+		&message,
+		&notification.BusinessID,
+		&notification.TicketID,
+		&notification.Status,
+		&notification.Attempts,
+		&notification.NextAttemptAt,
+		&notification.LastError,
+		&notification.MessageID,
+		&notification.SentAt,
+		&notification.CreatedAt,
+		&notification.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(message, &notification.Message); err != nil {
+		return nil, fmt.Errorf("failed to decode notification message: %w", err)
+	}
+	return notification, nil
+}
+
+// Enqueue stores a notification to be sent by the dispatcher
+func (r *notificationRepository) Enqueue(ctx context.Context, notification *models.Notification) error {
+	message, err := json.Marshal(notification.Message)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification message: %w", err)
+	}
+
+	query := `
+		INSERT INTO notifications (id, recipient, message, business_id, ticket_id, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err = r.pool.Exec(ctx, query,
+		notification.ID,
+		notification.Message.To,
+		message,
+		notification.BusinessID,
+		notification.TicketID,
+		notification.Status,
+		notification.Attempts,
+		notification.NextAttemptAt,
+		notification.CreatedAt,
+		notification.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue notification: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue returns up to limit pending notifications due at the given time, oldest first, and counts
+// the attempt about to be made. Their next attempt is pushed back by lease, so other dispatchers skip
+// them while they are being sent, and a notification whose dispatcher died is retried once the lease expires.
+func (r *notificationRepository) ClaimDue(ctx context.Context, at time.Time, limit int, lease time.Duration) ([]*models.Notification, error) {
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM notifications
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE notifications
+		SET attempts = attempts + 1, next_attempt_at = $3, updated_at = $1
+		WHERE id IN (SELECT id FROM due)
+		RETURNING ` + notificationColumns
+
+	rows, err := r.pool.Query(ctx, query, at, limit, at.Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// MarkSent records that the Graph API accepted the notification under the given message ID
+func (r *notificationRepository) MarkSent(ctx context.Context, id uuid.UUID, messageID string, at time.Time) error {
+	query := `
+		UPDATE notifications
+		SET status = 'sent', message_id = $2, sent_at = $3, last_error = NULL, updated_at = $3
+		WHERE id = $1
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, messageID, at); err != nil {
+		return fmt.Errorf("failed to mark notification as sent: %w", err)
+	}
+
+	return nil
+}
+
+// Reschedule records a failed attempt and the time of the next one
+func (r *notificationRepository) Reschedule(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	query := `
+		UPDATE notifications
+		SET next_attempt_at = $2, last_error = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, nextAttemptAt, lastError); err != nil {
+		return fmt.Errorf("failed to reschedule notification: %w", err)
+	}
+
+	return nil
+}
+
+// MarkDead records the failure of the last attempt; the notification is not retried anymore
+func (r *notificationRepository) MarkDead(ctx context.Context, id uuid.UUID, lastError string) error {
+	query := `
+		UPDATE notifications
+		SET status = 'dead', last_error = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, lastError); err != nil {
+		return fmt.Errorf("failed to mark notification as dead: %w", err)
+	}
+
+	return nil
+}
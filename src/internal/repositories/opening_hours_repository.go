@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OpeningHoursRepository defines the interface for business opening hours operations
+type OpeningHoursRepository interface {
+	FindByBusinessID(ctx context.Context, businessID uuid.UUID) ([]*models.OpeningHours, error)
+	Replace(ctx context.Context, businessID uuid.UUID, timezone string, hours []*models.OpeningHours) error
+}
+
+// openingHoursRepository implements OpeningHoursRepository
+type openingHoursRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOpeningHoursRepository creates a new instance of OpeningHoursRepository
+func NewOpeningHoursRepository(pool *pgxpool.Pool) OpeningHoursRepository {
+	return &openingHoursRepository{
+		pool: pool,
+	}
+}
+
+// FindByBusinessID retrieves the weekly opening hours of a business ordered by day and time
+func (r *openingHoursRepository) FindByBusinessID(ctx context.Context, businessID uuid.UUID) ([]*models.OpeningHours, error) {
+	query := `
+		SELECT id, business_id, weekday, to_char(opens_at, 'HH24:MI'), to_char(closes_at, 'HH24:MI')
+		FROM business_opening_hours
+		WHERE business_id = $1
+		ORDER BY weekday ASC, opens_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query opening hours: %w", err)
+	}
+	defer rows.Close()
+
+	var hours []*models.OpeningHours
+	for rows.Next() {
+		h := &models.OpeningHours{}
+		if err := rows.Scan(&h.ID, &h.BusinessID, &h.Weekday, &h.OpensAt, &h.ClosesAt); err != nil {
+			return nil, fmt.Errorf("failed to scan opening hours: %w", err)
+		}
+		hours = append(hours, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating opening hours: %w", err)
+	}
+
+	return hours, nil
+}
+
+// Replace atomically replaces the timezone and the whole weekly schedule of a business
+func (r *openingHoursRepository) Replace(ctx context.Context, businessID uuid.UUID, timezone string, hours []*models.OpeningHours) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `UPDATE businesses SET timezone = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`, businessID, timezone)
+	if err != nil {
+		return fmt.Errorf("failed to update business timezone: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("business not found")
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM business_opening_hours WHERE business_id = $1`, businessID); err != nil {
+		return fmt.Errorf("failed to clear opening hours: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO business_opening_hours (id, business_id, weekday, opens_at, closes_at)
+		VALUES ($1, $2, $3, $4::time, $5::time)
+	`
+	for _, h := range hours {
+		if _, err := tx.Exec(ctx, insertQuery, h.ID, h.BusinessID, int(h.Weekday), h.OpensAt, h.ClosesAt); err != nil {
+			return fmt.Errorf("failed to insert opening hours: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit opening hours: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// QueueRepository defines the interface for queue operations
+type QueueRepository interface {
+	Create(ctx context.Context, queue *models.Queue) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Queue, error)
+	FindByBusinessID(ctx context.Context, businessID uuid.UUID) ([]*models.Queue, error)
+	Update(ctx context.Context, queue *models.Queue) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// queueRepository implements QueueRepository
+type queueRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQueueRepository creates a new instance of QueueRepository
+func NewQueueRepository(pool *pgxpool.Pool) QueueRepository {
+	return &queueRepository{
+		pool: pool,
+	}
+}
+
+const queueColumns = `id, business_id, service_id, name, description, average_service_minutes, is_active, created_at, updated_at`
+
+// scanQueue scans a single queue row
+func scanQueue(row pgx.Row) (*models.Queue, error) {
+	queue := &models.Queue{}
+	err := row.Scan(
+		&queue.ID,
+		&queue.BusinessID,
+		&queue.ServiceID,
+		&queue.Name,
+		&queue.Description,
+		&queue.AverageServiceMinutes,
+		&queue.IsActive,
+		&queue.CreatedAt,
+		&queue.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+// Create inserts a new queue into the database
+func (r *queueRepository) Create(ctx context.Context, queue *models.Queue) error {
+	query := `
+		INSERT INTO queues (id, business_id, service_id, name, description, average_service_minutes, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		queue.ID,
+		queue.BusinessID,
+		queue.ServiceID,
+		queue.Name,
+		queue.Description,
+		queue.AverageServiceMinutes,
+		queue.IsActive,
+		queue.CreatedAt,
+		queue.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create queue: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a queue by ID
+func (r *queueRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Queue, error) {
+	query := `SELECT ` + queueColumns + ` FROM queues WHERE id = $1`
+
+	queue, err := scanQueue(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("queue not found")
+		}
+		return nil, fmt.Errorf("failed to find queue: %w", err)
+	}
+
+	return queue, nil
+}
+
+// FindByBusinessID retrieves all queues of a business
+func (r *queueRepository) FindByBusinessID(ctx context.Context, businessID uuid.UUID) ([]*models.Queue, error) {
+	query := `SELECT ` + queueColumns + ` FROM queues WHERE business_id = $1 ORDER BY created_at ASC`
+
+	rows, err := r.pool.Query(ctx, query, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query queues: %w", err)
+	}
+	defer rows.Close()
+
+	var queues []*models.Queue
+	for rows.Next() {
+		queue, err := scanQueue(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan queue: %w", err)
+		}
+		queues = append(queues, queue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating queues: %w", err)
+	}
+
+	return queues, nil
+}
+
+// Update updates an existing queue
+func (r *queueRepository) Update(ctx context.Context, queue *models.Queue) error {
+	query := `
+		UPDATE queues
+		SET service_id = $2, name = $3, description = $4, average_service_minutes = $5, is_active = $6, updated_at = $7
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query,
+		queue.ID,
+		queue.ServiceID,
+		queue.Name,
+		queue.Description,
+		queue.AverageServiceMinutes,
+		queue.IsActive,
+		queue.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update queue: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("queue not found")
+	}
+
+	return nil
+}
+
+// Delete removes a queue from the database
+func (r *queueRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM queues WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete queue: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("queue not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RefreshTokenRepository defines the interface for refresh token operations
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	Rotate(ctx context.Context, usedID uuid.UUID, next *models.RefreshToken) error
+	RevokeFamily(ctx context.Context, id, userID uuid.UUID) error
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// refreshTokenRepository implements RefreshTokenRepository
+type refreshTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRefreshTokenRepository creates a new instance of RefreshTokenRepository
+func NewRefreshTokenRepository(pool *pgxpool.Pool) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		pool: pool,
+	}
+}
+
+const refreshTokenColumns = `id, user_id, family_id, expires_at, used_at, replaced_by, revoked_at, created_at`
+
+// scanRefreshToken scans a single refresh token row
+func scanRefreshToken(row pgx.Row) (*models.RefreshToken, error) {
+	token := &models.RefreshToken{}
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.ReplacedBy,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Create stores a newly issued refresh token
+func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	return insertRefreshToken(ctx, r.pool, token)
+}
+
+// Rotate marks a refresh token as used and stores the token issued in exchange,
+// which joins the family of the used token.
+// If the token was already used, the whole family is revoked, since either the client
+// or an attacker holds a stolen copy; the revocation is kept even though Rotate fails.
+func (r *refreshTokenRepository) Rotate(ctx context.Context, usedID uuid.UUID, next *models.RefreshToken) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the token so two concurrent refreshes with the same token cannot both succeed
+	query := `SELECT ` + refreshTokenColumns + ` FROM refresh_tokens WHERE id = $1 FOR UPDATE`
+
+	used, err := scanRefreshToken(tx.QueryRow(ctx, query, usedID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("refresh token not found")
+		}
+		return fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	if used.UserID != next.UserID {
+		return fmt.Errorf("refresh token not found")
+	}
+
+	if used.RevokedAt != nil {
+		return fmt.Errorf("refresh token revoked")
+	}
+
+	now := time.Now()
+
+	if used.UsedAt != nil {
+		revokeQuery := `UPDATE refresh_tokens SET revoked_at = $2 WHERE family_id = $1 AND revoked_at IS NULL`
+		if _, err := tx.Exec(ctx, revokeQuery, used.FamilyID, now); err != nil {
+			return fmt.Errorf("failed to revoke refresh token family: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit refresh token revocation: %w", err)
+		}
+		return fmt.Errorf("refresh token reuse detected")
+	}
+
+	next.FamilyID = used.FamilyID
+
+	markQuery := `UPDATE refresh_tokens SET used_at = $2, replaced_by = $3 WHERE id = $1`
+	if _, err := tx.Exec(ctx, markQuery, usedID, now, next.ID); err != nil {
+		return fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+
+	if err := insertRefreshToken(ctx, tx, next); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit refresh token rotation: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes a refresh token of the user together with every token rotated from the same login.
+// Unknown or already revoked tokens are ignored.
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, id, userID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE id = $1 AND user_id = $2) AND revoked_at IS NULL
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllForUser revokes every refresh token of the user that is not yet revoked
+func (r *refreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.pool.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// insertRefreshToken writes a refresh token using the given pool or transaction
+func insertRefreshToken(ctx context.Context, db execer, token *models.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := db.Exec(ctx, query,
+		token.ID,
+		token.UserID,
+		token.FamilyID,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ServiceOfferingRepository defines the interface for service catalog operations
+type ServiceOfferingRepository interface {
+	Create(ctx context.Context, offering *models.ServiceOffering) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.ServiceOffering, error)
+	FindByBusinessID(ctx context.Context, businessID uuid.UUID) ([]*models.ServiceOffering, error)
+	Update(ctx context.Context, offering *models.ServiceOffering) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// serviceOfferingRepository implements ServiceOfferingRepository
+type serviceOfferingRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewServiceOfferingRepository creates a new instance of ServiceOfferingRepository
+func NewServiceOfferingRepository(pool *pgxpool.Pool) ServiceOfferingRepository {
+	return &serviceOfferingRepository{
+		pool: pool,
+	}
+}
+
+const serviceOfferingColumns = `id, business_id, name, description, average_duration_minutes, price_cents, check_in_radius_meters, late_tolerance_minutes, is_active, created_at, updated_at`
+
+// scanServiceOffering scans a single service offering row
+func scanServiceOffering(row pgx.Row) (*models.ServiceOffering, error) {
+	offering := &models.ServiceOffering{}
+	err := row.Scan(
+		&offering.ID,
+		&offering.BusinessID,
+		&offering.Name,
+		&offering.Description,
+		&offering.AverageDurationMinutes,
+		&offering.PriceCents,
+		&offering.CheckInRadiusMeters,
+		&offering.LateToleranceMinutes,
+		&offering.IsActive,
+		&offering.CreatedAt,
+		&offering.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return offering, nil
+}
+
+// Create inserts a new service offering into the database
+func (r *serviceOfferingRepository) Create(ctx context.Context, offering *models.ServiceOffering) error {
+	query := `
+		INSERT INTO service_offerings (id, business_id, name, description, average_duration_minutes, price_cents,
+			check_in_radius_meters, late_tolerance_minutes, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		offering.ID,
+		offering.BusinessID,
+		offering.Name,
+		offering.Description,
+		offering.AverageDurationMinutes,
+		offering.PriceCents,
+		offering.CheckInRadiusMeters,
+		offering.LateToleranceMinutes,
+		offering.IsActive,
+		offering.CreatedAt,
+		offering.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create service offering: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a service offering by ID
+func (r *serviceOfferingRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.ServiceOffering, error) {
+	query := `SELECT ` + serviceOfferingColumns + ` FROM service_offerings WHERE id = $1`
+
+	offering, err := scanServiceOffering(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("service offering not found")
+		}
+		return nil, fmt.Errorf("failed to find service offering: %w", err)
+	}
+
+	return offering, nil
+}
+
+// FindByBusinessID retrieves the service catalog of a business
+func (r *serviceOfferingRepository) FindByBusinessID(ctx context.Context, businessID uuid.UUID) ([]*models.ServiceOffering, error) {
+	query := `SELECT ` + serviceOfferingColumns + ` FROM service_offerings WHERE business_id = $1 ORDER BY name ASC`
+
+	rows, err := r.pool.Query(ctx, query, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query service offerings: %w", err)
+	}
+	defer rows.Close()
+
+	var offerings []*models.ServiceOffering
+	for rows.Next() {
+		offering, err := scanServiceOffering(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan service offering: %w", err)
+		}
+		offerings = append(offerings, offering)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating service offerings: %w", err)
+	}
+
+	return offerings, nil
+}
+
+// Update updates an existing service offering
+func (r *serviceOfferingRepository) Update(ctx context.Context, offering *models.ServiceOffering) error {
+	query := `
+		UPDATE service_offerings
+		SET name = $2, description = $3, average_duration_minutes = $4, price_cents = $5,
+			check_in_radius_meters = $6, late_tolerance_minutes = $7, is_active = $8, updated_at = $9
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query,
+		offering.ID,
+		offering.Name,
+		offering.Description,
+		offering.AverageDurationMinutes,
+		offering.PriceCents,
+		offering.CheckInRadiusMeters,
+		offering.LateToleranceMinutes,
+		offering.IsActive,
+		offering.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update service offering: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("service offering not found")
+	}
+
+	return nil
+}
+
+// Delete removes a service offering from the database
+func (r *serviceOfferingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM service_offerings WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete service offering: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("service offering not found")
+	}
+
+	return nil
+}
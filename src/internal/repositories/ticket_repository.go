@@ -0,0 +1,550 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TicketRepository defines the interface for ticket operations
+type TicketRepository interface {
+	Create(ctx context.Context, ticket *models.Ticket) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Ticket, error)
+	FindByQueueID(ctx context.Context, queueID uuid.UUID, statuses []models.TicketStatus) ([]*models.Ticket, error)
+	FindActiveByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*models.Ticket, error)
+	FindScheduledInRange(ctx context.Context, queueID uuid.UUID, from, to time.Time) ([]*models.Ticket, error)
+	CountCustomerOutcomes(ctx context.Context, customerID uuid.UUID) (models.TicketOutcomes, error)
+	CallNext(ctx context.Context, queueID uuid.UUID, at time.Time) (*models.Ticket, error)
+	UpdateStatus(ctx context.Context, ticket *models.Ticket, fromStatus models.TicketStatus) error
+	UpdateCheckIn(ctx context.Context, ticket *models.Ticket) error
+	Cancel(ctx context.Context, ticket *models.Ticket, fromStatus models.TicketStatus) error
+	Reschedule(ctx context.Context, ticket *models.Ticket) error
+	MarkOverdueNoShows(ctx context.Context, at time.Time, defaultTolerance time.Duration) ([]*models.Ticket, error)
+}
+
+// ticketRepository implements TicketRepository
+type ticketRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTicketRepository creates a new instance of TicketRepository
+func NewTicketRepository(pool *pgxpool.Pool) TicketRepository {
+	return &ticketRepository{
+		pool: pool,
+	}
+}
+
+const ticketColumns = `id, queue_id, business_id, customer_id, number, status, scheduled_at, scheduled_until, called_at, started_at, finished_at, late_cancellation, check_in_status, check_in_distance_meters, checked_in_at, created_at, updated_at`
+
+// scanTicket scans a single ticket row
+func scanTicket(row pgx.Row) (*models.Ticket, error) {
+	ticket := &models.Ticket{}
+	err := row.Scan(
+		&ticket.ID,
+		&ticket.QueueID,
+		&ticket.BusinessID,
+		&ticket.CustomerID,
+		&ticket.Number,
+		&ticket.Status,
+		&ticket.ScheduledAt,
+		&ticket.ScheduledUntil,
+		&ticket.CalledAt,
+		&ticket.StartedAt,
+		&ticket.FinishedAt,
+		&ticket.LateCancellation,
+		&ticket.CheckInStatus,
+		&ticket.CheckInDistanceMeters,
+		&ticket.CheckedInAt,
+		&ticket.CreatedAt,
+		&ticket.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// statusFilter converts ticket statuses into a text array query parameter
+func statusFilter(statuses []models.TicketStatus) []string {
+	filter := make([]string, len(statuses))
+	for i, status := range statuses {
+		filter[i] = string(status)
+	}
+	return filter
+}
+
+// scanTickets scans all ticket rows and closes them
+func scanTickets(rows pgx.Rows) ([]*models.Ticket, error) {
+	defer rows.Close()
+
+	var tickets []*models.Ticket
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ticket: %w", err)
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tickets: %w", err)
+	}
+
+	return tickets, nil
+}
+
+// Create inserts a new ticket, assigning the next sequential number of its queue.
+// A walk-in ticket fails if the customer already holds an active walk-in ticket in the same queue;
+// an appointment fails if its slot overlaps another active appointment of the queue.
+func (r *ticketRepository) Create(ctx context.Context, ticket *models.Ticket) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Reserve the ticket number; the row lock serializes concurrent joins on the same queue
+	numberQuery := `
+		UPDATE queues
+		SET next_ticket_number = next_ticket_number + 1
+		WHERE id = $1
+		RETURNING next_ticket_number - 1
+	`
+	if err := tx.QueryRow(ctx, numberQuery, ticket.QueueID).Scan(&ticket.Number); err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("queue not found")
+		}
+		return fmt.Errorf("failed to reserve ticket number: %w", err)
+	}
+
+	// With the queue row locked, concurrent joins and bookings cannot slip past these checks
+	if ticket.IsAppointment() {
+		if err := checkAppointmentSlot(ctx, tx, ticket); err != nil {
+			return err
+		}
+	} else {
+		var alreadyQueued bool
+		existsQuery := `
+			SELECT EXISTS (
+				SELECT 1 FROM tickets
+				WHERE queue_id = $1 AND customer_id = $2 AND scheduled_at IS NULL AND status = ANY($3::text[])
+			)
+		`
+		if err := tx.QueryRow(ctx, existsQuery, ticket.QueueID, ticket.CustomerID, statusFilter(models.ActiveTicketStatuses)).Scan(&alreadyQueued); err != nil {
+			return fmt.Errorf("failed to check active tickets: %w", err)
+		}
+		if alreadyQueued {
+			return fmt.Errorf("customer already has an active ticket in this queue")
+		}
+	}
+
+	insertQuery := `
+		INSERT INTO tickets (id, queue_id, business_id, customer_id, number, status, scheduled_at, scheduled_until, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err = tx.Exec(ctx, insertQuery,
+		ticket.ID,
+		ticket.QueueID,
+		ticket.BusinessID,
+		ticket.CustomerID,
+		ticket.Number,
+		ticket.Status,
+		ticket.ScheduledAt,
+		ticket.ScheduledUntil,
+		ticket.CreatedAt,
+		ticket.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create ticket: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit ticket: %w", err)
+	}
+
+	return nil
+}
+
+// checkAppointmentSlot fails if the slot of an appointment overlaps another active appointment
+// of its queue. It must run in a transaction holding the queue row lock.
+func checkAppointmentSlot(ctx context.Context, tx pgx.Tx, ticket *models.Ticket) error {
+	var slotTaken bool
+	slotQuery := `
+		SELECT EXISTS (
+			SELECT 1 FROM tickets
+			WHERE queue_id = $1 AND id <> $2 AND scheduled_at < $4 AND scheduled_until > $3 AND status = ANY($5::text[])
+		)
+	`
+	if err := tx.QueryRow(ctx, slotQuery, ticket.QueueID, ticket.ID, ticket.ScheduledAt, ticket.ScheduledUntil, statusFilter(models.ActiveTicketStatuses)).Scan(&slotTaken); err != nil {
+		return fmt.Errorf("failed to check appointment slot: %w", err)
+	}
+	if slotTaken {
+		return fmt.Errorf("appointment slot is already booked")
+	}
+	return nil
+}
+
+// FindByID retrieves a ticket by ID
+func (r *ticketRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Ticket, error) {
+	query := `SELECT ` + ticketColumns + ` FROM tickets WHERE id = $1`
+
+	ticket, err := scanTicket(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("ticket not found")
+		}
+		return nil, fmt.Errorf("failed to find ticket: %w", err)
+	}
+
+	return ticket, nil
+}
+
+// FindByQueueID retrieves the tickets of a queue in queue order, optionally filtered by status
+func (r *ticketRepository) FindByQueueID(ctx context.Context, queueID uuid.UUID, statuses []models.TicketStatus) ([]*models.Ticket, error) {
+	query := `
+		SELECT ` + ticketColumns + `
+		FROM tickets
+		WHERE queue_id = $1 AND (cardinality($2::text[]) = 0 OR status = ANY($2::text[]))
+		ORDER BY number ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, queueID, statusFilter(statuses))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tickets: %w", err)
+	}
+
+	return scanTickets(rows)
+}
+
+// FindActiveByCustomerID retrieves the tickets of a customer that still hold a place in a queue
+func (r *ticketRepository) FindActiveByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*models.Ticket, error) {
+	query := `
+		SELECT ` + ticketColumns + `
+		FROM tickets
+		WHERE customer_id = $1 AND status = ANY($2::text[])
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, customerID, statusFilter(models.ActiveTicketStatuses))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tickets: %w", err)
+	}
+
+	return scanTickets(rows)
+}
+
+// FindScheduledInRange retrieves the active appointments of a queue whose slot overlaps [from, to)
+func (r *ticketRepository) FindScheduledInRange(ctx context.Context, queueID uuid.UUID, from, to time.Time) ([]*models.Ticket, error) {
+	query := `
+		SELECT ` + ticketColumns + `
+		FROM tickets
+		WHERE queue_id = $1 AND scheduled_at < $3 AND scheduled_until > $2 AND status = ANY($4::text[])
+		ORDER BY scheduled_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, queueID, from, to, statusFilter(models.ActiveTicketStatuses))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query appointments: %w", err)
+	}
+
+	return scanTickets(rows)
+}
+
+// CountCustomerOutcomes counts the finished tickets of a customer that affect their reputation
+func (r *ticketRepository) CountCustomerOutcomes(ctx context.Context, customerID uuid.UUID) (models.TicketOutcomes, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'done'),
+			COUNT(*) FILTER (WHERE status = 'cancelled' AND late_cancellation),
+			COUNT(*) FILTER (WHERE status = 'no_show')
+		FROM tickets
+		WHERE customer_id = $1
+	`
+
+	var outcomes models.TicketOutcomes
+	err := r.pool.QueryRow(ctx, query, customerID).Scan(
+		&outcomes.Completed,
+		&outcomes.LateCancellations,
+		&outcomes.NoShows,
+	)
+	if err != nil {
+		return outcomes, fmt.Errorf("failed to count ticket outcomes: %w", err)
+	}
+
+	return outcomes, nil
+}
+
+// CallNext atomically moves the next waiting ticket of a queue to called.
+// Appointments whose slot has started go first, in slot order, then walk-ins in number order;
+// appointments whose slot has not started yet are not called.
+// Rows locked by a concurrent caller are skipped, so two callers never receive the same ticket.
+func (r *ticketRepository) CallNext(ctx context.Context, queueID uuid.UUID, at time.Time) (*models.Ticket, error) {
+	query := `
+		UPDATE tickets
+		SET status = 'called', called_at = $2, updated_at = $2
+		WHERE id = (
+			SELECT id FROM tickets
+			WHERE queue_id = $1 AND status = 'waiting' AND (scheduled_at IS NULL OR scheduled_at <= $2)
+			ORDER BY scheduled_at ASC NULLS LAST, number ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + ticketColumns
+
+	ticket, err := scanTicket(r.pool.QueryRow(ctx, query, queueID, at))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("no tickets waiting in this queue")
+		}
+		return nil, fmt.Errorf("failed to call next ticket: %w", err)
+	}
+
+	return ticket, nil
+}
+
+// UpdateStatus persists a status transition only if the ticket is still in fromStatus,
+// so concurrent transitions on the same ticket cannot both succeed
+func (r *ticketRepository) UpdateStatus(ctx context.Context, ticket *models.Ticket, fromStatus models.TicketStatus) error {
+	query := `
+		UPDATE tickets
+		SET status = $2, called_at = $3, started_at = $4, finished_at = $5, updated_at = $6
+		WHERE id = $1 AND status = $7
+	`
+
+	result, err := r.pool.Exec(ctx, query,
+		ticket.ID,
+		ticket.Status,
+		ticket.CalledAt,
+		ticket.StartedAt,
+		ticket.FinishedAt,
+		ticket.UpdatedAt,
+		fromStatus,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update ticket status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("ticket status changed concurrently")
+	}
+
+	return nil
+}
+
+// UpdateCheckIn persists the latest check-in result of an active ticket
+func (r *ticketRepository) UpdateCheckIn(ctx context.Context, ticket *models.Ticket) error {
+	query := `
+		UPDATE tickets
+		SET check_in_status = $2, check_in_distance_meters = $3, checked_in_at = $4, updated_at = $5
+		WHERE id = $1 AND status = ANY($6::text[])
+	`
+
+	result, err := r.pool.Exec(ctx, query,
+		ticket.ID,
+		ticket.CheckInStatus,
+		ticket.CheckInDistanceMeters,
+		ticket.CheckedInAt,
+		ticket.UpdatedAt,
+		statusFilter(models.ActiveTicketStatuses),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update ticket check-in: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("ticket is no longer active")
+	}
+
+	return nil
+}
+
+// Cancel persists a customer cancellation only if the ticket is still in fromStatus,
+// recording a domain event so the business is notified
+func (r *ticketRepository) Cancel(ctx context.Context, ticket *models.Ticket, fromStatus models.TicketStatus) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE tickets
+		SET status = $2, late_cancellation = $3, finished_at = $4, updated_at = $5
+		WHERE id = $1 AND status = $6
+	`
+
+	result, err := tx.Exec(ctx, query,
+		ticket.ID,
+		ticket.Status,
+		ticket.LateCancellation,
+		ticket.FinishedAt,
+		ticket.UpdatedAt,
+		fromStatus,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to cancel ticket: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("ticket status changed concurrently")
+	}
+
+	if err := insertDomainEvent(ctx, tx, models.NewTicketEvent(models.DomainEventTicketCancelled, ticket, ticket.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit ticket cancellation: %w", err)
+	}
+
+	return nil
+}
+
+// Reschedule moves a waiting ticket to its new time slot, recording a domain event so the
+// business is notified. It fails if the slot overlaps another active appointment of the queue.
+func (r *ticketRepository) Reschedule(ctx context.Context, ticket *models.Ticket) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the queue row like Create does, so bookings cannot take the slot concurrently
+	var queueID uuid.UUID
+	if err := tx.QueryRow(ctx, `SELECT id FROM queues WHERE id = $1 FOR UPDATE`, ticket.QueueID).Scan(&queueID); err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("queue not found")
+		}
+		return fmt.Errorf("failed to lock queue: %w", err)
+	}
+
+	if err := checkAppointmentSlot(ctx, tx, ticket); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE tickets
+		SET scheduled_at = $2, scheduled_until = $3, updated_at = $4
+		WHERE id = $1 AND status = 'waiting'
+	`
+
+	result, err := tx.Exec(ctx, query,
+		ticket.ID,
+		ticket.ScheduledAt,
+		ticket.ScheduledUntil,
+		ticket.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to reschedule ticket: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("ticket status changed concurrently")
+	}
+
+	if err := insertDomainEvent(ctx, tx, models.NewTicketEvent(models.DomainEventTicketRescheduled, ticket, ticket.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit ticket reschedule: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOverdueNoShows moves called and skipped tickets past the tolerance of the queue's service
+// to no_show, recording a domain event for each. Services without a tolerance use defaultTolerance.
+// Only overdue tickets are selected and locked, so tickets staff are working on stay unlocked;
+// Ticket.IsNoShowAt re-checks each one before it changes status. Rows locked by a concurrent console
+// action are skipped and picked up by a later sweep if still overdue.
+func (r *ticketRepository) MarkOverdueNoShows(ctx context.Context, at time.Time, defaultTolerance time.Duration) ([]*models.Ticket, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Each status branch matches the partial index of its status
+	candidatesQuery := `
+		SELECT t.id, t.status, t.called_at, t.check_in_status, t.updated_at, tolerance.seconds
+		FROM tickets t
+		JOIN queues q ON q.id = t.queue_id
+		LEFT JOIN service_offerings so ON so.id = q.service_id
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(NULLIF(so.late_tolerance_minutes, 0) * 60, $2) AS seconds
+		) tolerance
+		WHERE (
+			t.status = 'called'
+			AND t.check_in_status IS DISTINCT FROM 'accepted'
+			AND t.called_at + make_interval(secs => tolerance.seconds) < $1
+		) OR (
+			t.status = 'skipped'
+			AND t.updated_at + make_interval(secs => tolerance.seconds) < $1
+		)
+		FOR UPDATE OF t SKIP LOCKED
+	`
+
+	candidates, err := tx.Query(ctx, candidatesQuery, at, int(defaultTolerance.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find no-show candidates: %w", err)
+	}
+
+	var overdue []uuid.UUID
+	for candidates.Next() {
+		ticket := &models.Ticket{}
+		var toleranceSeconds int
+		if err := candidates.Scan(&ticket.ID, &ticket.Status, &ticket.CalledAt, &ticket.CheckInStatus, &ticket.UpdatedAt, &toleranceSeconds); err != nil {
+			candidates.Close()
+			return nil, fmt.Errorf("failed to scan no-show candidate: %w", err)
+		}
+		if ticket.IsNoShowAt(at, time.Duration(toleranceSeconds)*time.Second) {
+			overdue = append(overdue, ticket.ID)
+		}
+	}
+	candidates.Close()
+	if err := candidates.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating no-show candidates: %w", err)
+	}
+
+	if len(overdue) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		UPDATE tickets
+		SET status = 'no_show', finished_at = $1, updated_at = $1
+		WHERE id = ANY($2)
+		RETURNING ` + ticketColumns
+
+	rows, err := tx.Query(ctx, query, at, overdue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark no-show tickets: %w", err)
+	}
+
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ticket := range tickets {
+		if err := insertDomainEvent(ctx, tx, models.NewTicketEvent(models.DomainEventTicketNoShow, ticket, at)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit no-show tickets: %w", err)
+	}
+
+	return tickets, nil
+}
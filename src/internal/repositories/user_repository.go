@@ -4,6 +4,8 @@ import (
 	"context"
 	"easy-queue-go/src/internal/models"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -14,9 +16,17 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	FindByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.User, error)
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByVerifiedPhone(ctx context.Context, phone string) (*models.User, error)
 	FindAll(ctx context.Context) ([]*models.User, error)
-	Update(ctx context.Context, user *models.User) error
+	UpdatePhone(ctx context.Context, id uuid.UUID, phone string, at time.Time) (bool, error)
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, at time.Time) error
+	UpdateReputation(ctx context.Context, id uuid.UUID, reputation models.Reputation) error
+	InvalidateTokens(ctx context.Context, id uuid.UUID, at time.Time) error
+	MarkPhoneVerified(ctx context.Context, id uuid.UUID, phone string, at time.Time) error
+	UpdateAccess(ctx context.Context, user *models.User) error
+	CountActiveAdmins(ctx context.Context) (int, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -57,26 +67,61 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	return nil
 }
 
-// FindByID retrieves a user by ID
-func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
-	query := `
-		SELECT id, email, password_hash, phone, roles, is_active, created_at, updated_at
-		FROM users
-		WHERE id = $1
-	`
+const userColumns = `id, email, password_hash, phone, roles, is_active,
+	reputation_score, completed_tickets, late_cancellations, no_shows, reputation_updated_at,
+	phone_verified_at, tokens_valid_after, created_at, updated_at`
 
+// scanUser scans a single user row
+func scanUser(row pgx.Row) (*models.User, error) {
 	user := &models.User{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := row.Scan(
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
 		&user.Phone,
 		&user.Roles,
 		&user.IsActive,
+		&user.Reputation.Score,
+		&user.Reputation.CompletedTickets,
+		&user.Reputation.LateCancellations,
+		&user.Reputation.NoShows,
+		&user.Reputation.UpdatedAt,
+		&user.PhoneVerifiedAt,
+		&user.TokensValidAfter,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
 
+// scanUsers scans all user rows, closing them when done
+func scanUsers(rows pgx.Rows) ([]*models.User, error) {
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, nil
+}
+
+// FindByID retrieves a user by ID
+func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	user, err := scanUser(r.pool.QueryRow(ctx, query, id))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("user not found")
@@ -87,26 +132,46 @@ func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Us
 	return user, nil
 }
 
+// FindByIDs retrieves the users with the given IDs; unknown IDs are ignored
+func (r *userRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ANY($1)`
+
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+
+	return scanUsers(rows)
+}
+
 // FindByEmail retrieves a user by email
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
+
+	user, err := scanUser(r.pool.QueryRow(ctx, query, email))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return user, nil
+}
+
+// FindByVerifiedPhone retrieves the user who verified the phone number most recently.
+// Numbers are compared by their digits only, so "+1 650-555-1234" matches "16505551234";
+// the digits of stored numbers are kept in the indexed phone_digits column.
+func (r *userRepository) FindByVerifiedPhone(ctx context.Context, phone string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, phone, roles, is_active, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
-		WHERE email = $1
+		WHERE phone_digits = $1 AND phone_verified_at IS NOT NULL
+		ORDER BY phone_verified_at DESC
+		LIMIT 1
 	`
 
-	user := &models.User{}
-	err := r.pool.QueryRow(ctx, query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.Phone,
-		&user.Roles,
-		&user.IsActive,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanUser(r.pool.QueryRow(ctx, query, phoneDigits(phone)))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("user not found")
@@ -117,66 +182,91 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models
 	return user, nil
 }
 
+// phoneDigits strips a phone number down to its digits, like the phone_digits column
+func phoneDigits(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
 // FindAll returns all users
 func (r *userRepository) FindAll(ctx context.Context) ([]*models.User, error) {
-	query := `
-		SELECT id, email, password_hash, phone, roles, is_active, created_at, updated_at
-		FROM users
-		ORDER BY created_at DESC
-	`
+	query := `SELECT ` + userColumns + ` FROM users ORDER BY created_at DESC`
 
 	rows, err := r.pool.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query users: %w", err)
 	}
-	defer rows.Close()
 
-	var users []*models.User
-	for rows.Next() {
-		user := &models.User{}
-		err := rows.Scan(
-			&user.ID,
-			&user.Email,
-			&user.PasswordHash,
-			&user.Phone,
-			&user.Roles,
-			&user.IsActive,
-			&user.CreatedAt,
-			&user.UpdatedAt,
+	return scanUsers(rows)
+}
+
+// UpdatePhone sets the phone number of a user and reports whether it changed. A changed number is no
+// longer verified. Only the phone columns are written, so concurrent password changes or phone
+// verifications are not overwritten with a stale copy of the user.
+func (r *userRepository) UpdatePhone(ctx context.Context, id uuid.UUID, phone string, at time.Time) (bool, error) {
+	query := `
+		WITH previous AS (
+			SELECT phone FROM users WHERE id = $1 FOR UPDATE
 		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan user: %w", err)
+		UPDATE users
+		SET phone = $2,
+			phone_verified_at = CASE WHEN previous.phone = $2 THEN users.phone_verified_at END,
+			updated_at = $3
+		FROM previous
+		WHERE users.id = $1
+		RETURNING previous.phone <> $2
+	`
+
+	var changed bool
+	if err := r.pool.QueryRow(ctx, query, id, phone, at).Scan(&changed); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, fmt.Errorf("user not found")
 		}
-		users = append(users, user)
+		return false, fmt.Errorf("failed to update user phone: %w", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating users: %w", err)
+	return changed, nil
+}
+
+// UpdatePassword stores the new password hash of a user
+func (r *userRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, at time.Time) error {
+	query := `UPDATE users SET password_hash = $2, updated_at = $3 WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, id, passwordHash, at)
+	if err != nil {
+		return fmt.Errorf("failed to update user password: %w", err)
 	}
 
-	return users, nil
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
 }
 
-// Update updates an existing user
-func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+// UpdateReputation stores the recalculated reputation of a user
+func (r *userRepository) UpdateReputation(ctx context.Context, id uuid.UUID, reputation models.Reputation) error {
 	query := `
 		UPDATE users
-		SET email = $2, password_hash = $3, phone = $4, roles = $5, is_active = $6, updated_at = $7
+		SET reputation_score = $2, completed_tickets = $3, late_cancellations = $4, no_shows = $5, reputation_updated_at = $6
 		WHERE id = $1
 	`
 
 	result, err := r.pool.Exec(ctx, query,
-		user.ID,
-		user.Email,
-		user.PasswordHash,
-		user.Phone,
-		user.Roles,
-		user.IsActive,
-		user.UpdatedAt,
+		id,
+		reputation.Score,
+		reputation.CompletedTickets,
+		reputation.LateCancellations,
+		reputation.NoShows,
+		reputation.UpdatedAt,
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+		return fmt.Errorf("failed to update user reputation: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -186,13 +276,13 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 	return nil
 }
 
-// Delete removes a user from the database
-func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM users WHERE id = $1`
+// InvalidateTokens rejects every token of the user issued before the given time
+func (r *userRepository) InvalidateTokens(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE users SET tokens_valid_after = $2 WHERE id = $1`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	result, err := r.pool.Exec(ctx, query, id, at)
 	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+		return fmt.Errorf("failed to invalidate user tokens: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -201,3 +291,105 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+// MarkPhoneVerified records that the user verified the given phone number.
+// It fails if the user's phone number changed since the verification code was sent.
+func (r *userRepository) MarkPhoneVerified(ctx context.Context, id uuid.UUID, phone string, at time.Time) error {
+	query := `UPDATE users SET phone_verified_at = $3 WHERE id = $1 AND phone = $2`
+
+	result, err := r.pool.Exec(ctx, query, id, phone, at)
+	if err != nil {
+		return fmt.Errorf("failed to mark phone as verified: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("phone number changed during verification")
+	}
+
+	return nil
+}
+
+// UpdateAccess stores the active flag and roles of a user.
+// It fails without changes if no active admin would remain.
+func (r *userRepository) UpdateAccess(ctx context.Context, user *models.User) error {
+	return r.keepingAnAdmin(ctx, func(tx pgx.Tx) error {
+		query := `UPDATE users SET is_active = $2, roles = $3, updated_at = $4 WHERE id = $1`
+
+		result, err := tx.Exec(ctx, query, user.ID, user.IsActive, user.Roles, user.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to update user access: %w", err)
+		}
+
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("user not found")
+		}
+
+		return nil
+	})
+}
+
+// Delete removes a user from the database.
+// It fails without changes if no active admin would remain.
+func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.keepingAnAdmin(ctx, func(tx pgx.Tx) error {
+		query := `DELETE FROM users WHERE id = $1`
+
+		result, err := tx.Exec(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("user not found")
+		}
+
+		return nil
+	})
+}
+
+// CountActiveAdmins returns the number of active users with the admin role
+func (r *userRepository) CountActiveAdmins(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM users WHERE is_active AND $1 = ANY(roles)`
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, models.RoleAdmin).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count admins: %w", err)
+	}
+
+	return count, nil
+}
+
+// keepingAnAdmin runs a change in a transaction that is rolled back if it leaves no active admin.
+// The active admins are locked first, so two concurrent changes cannot each remove one of the last two.
+func (r *userRepository) keepingAnAdmin(ctx context.Context, change func(tx pgx.Tx) error) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	lockQuery := `SELECT id FROM users WHERE is_active AND $1 = ANY(roles) FOR UPDATE`
+	if _, err := tx.Exec(ctx, lockQuery, models.RoleAdmin); err != nil {
+		return fmt.Errorf("failed to lock admins: %w", err)
+	}
+
+	if err := change(tx); err != nil {
+		return err
+	}
+
+	var admins int
+	countQuery := `SELECT COUNT(*) FROM users WHERE is_active AND $1 = ANY(roles)`
+	if err := tx.QueryRow(ctx, countQuery, models.RoleAdmin).Scan(&admins); err != nil {
+		return fmt.Errorf("failed to count admins: %w", err)
+	}
+
+	if admins == 0 {
+		return fmt.Errorf("cannot remove the last admin")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
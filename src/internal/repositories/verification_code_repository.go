@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// VerificationCodeRepository defines the interface for verification code operations
+type VerificationCodeRepository interface {
+	Create(ctx context.Context, code *models.VerificationCode) error
+	FindLatest(ctx context.Context, userID uuid.UUID, purpose models.VerificationPurpose) (*models.VerificationCode, error)
+	ReserveAttempt(ctx context.Context, userID uuid.UUID, purpose models.VerificationPurpose, maxAttempts int, at time.Time) (*models.VerificationCode, error)
+	Consume(ctx context.Context, id uuid.UUID, at time.Time) error
+	Invalidate(ctx context.Context, userID uuid.UUID, purpose models.VerificationPurpose, at time.Time) error
+}
+
+// verificationCodeRepository implements VerificationCodeRepository
+type verificationCodeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewVerificationCodeRepository creates a new instance of VerificationCodeRepository
+func NewVerificationCodeRepository(pool *pgxpool.Pool) VerificationCodeRepository {
+	return &verificationCodeRepository{
+		pool: pool,
+	}
+}
+
+const verificationCodeColumns = `id, user_id, purpose, phone, code_hash, expires_at, attempts, consumed_at, created_at`
+
+// scanVerificationCode scans a single verification code row
+func scanVerificationCode(row pgx.Row) (*models.VerificationCode, error) {
+	code := &models.VerificationCode{}
+	err := row.Scan(
+		&code.ID,
+		&code.UserID,
+		&code.Purpose,
+		&code.Phone,
+		&code.CodeHash,
+		&code.ExpiresAt,
+		&code.Attempts,
+		&code.ConsumedAt,
+		&code.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// Create stores a new verification code, superseding the unused codes of the user for the same purpose
+func (r *verificationCodeRepository) Create(ctx context.Context, code *models.VerificationCode) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := invalidateVerificationCodes(ctx, tx, code.UserID, code.Purpose, code.CreatedAt); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO verification_codes (id, user_id, purpose, phone, code_hash, expires_at, attempts, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err = tx.Exec(ctx, insertQuery,
+		code.ID,
+		code.UserID,
+		code.Purpose,
+		code.Phone,
+		code.CodeHash,
+		code.ExpiresAt,
+		code.Attempts,
+		code.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create verification code: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit verification code: %w", err)
+	}
+
+	return nil
+}
+
+// FindLatest retrieves the unused verification code of a user for a purpose
+func (r *verificationCodeRepository) FindLatest(ctx context.Context, userID uuid.UUID, purpose models.VerificationPurpose) (*models.VerificationCode, error) {
+	query := `
+		SELECT ` + verificationCodeColumns + `
+		FROM verification_codes
+		WHERE user_id = $1 AND purpose = $2 AND consumed_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	code, err := scanVerificationCode(r.pool.QueryRow(ctx, query, userID, purpose))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("verification code not found")
+		}
+		return nil, fmt.Errorf("failed to find verification code: %w", err)
+	}
+
+	return code, nil
+}
+
+// ReserveAttempt counts an attempt against the unused code of a user for a purpose and returns the code,
+// if it is not expired and has attempts left. The count is taken before the code is compared, in a single
+// statement, so concurrent guesses cannot exceed maxAttempts.
+func (r *verificationCodeRepository) ReserveAttempt(ctx context.Context, userID uuid.UUID, purpose models.VerificationPurpose, maxAttempts int, at time.Time) (*models.VerificationCode, error) {
+	query := `
+		UPDATE verification_codes
+		SET attempts = attempts + 1
+		WHERE id = (
+			SELECT id FROM verification_codes
+			WHERE user_id = $1 AND purpose = $2 AND consumed_at IS NULL
+			ORDER BY created_at DESC
+			LIMIT 1
+		) AND consumed_at IS NULL AND expires_at > $4 AND attempts < $3
+		RETURNING ` + verificationCodeColumns
+
+	code, err := scanVerificationCode(r.pool.QueryRow(ctx, query, userID, purpose, maxAttempts, at))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("verification code not found")
+		}
+		return nil, fmt.Errorf("failed to reserve verification attempt: %w", err)
+	}
+
+	return code, nil
+}
+
+// Consume marks a verification code as used. It fails if the code was already used,
+// so a code cannot be redeemed twice by concurrent requests.
+func (r *verificationCodeRepository) Consume(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE verification_codes SET consumed_at = $2 WHERE id = $1 AND consumed_at IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to consume verification code: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("verification code already used")
+	}
+
+	return nil
+}
+
+// Invalidate marks the unused codes of a user for a purpose as consumed, e.g. when the phone number
+// they were sent to is no longer the user's
+func (r *verificationCodeRepository) Invalidate(ctx context.Context, userID uuid.UUID, purpose models.VerificationPurpose, at time.Time) error {
+	return invalidateVerificationCodes(ctx, r.pool, userID, purpose, at)
+}
+
+// invalidateVerificationCodes consumes the unused codes of a user for a purpose using the given pool or transaction
+func invalidateVerificationCodes(ctx context.Context, db execer, userID uuid.UUID, purpose models.VerificationPurpose, at time.Time) error {
+	query := `
+		UPDATE verification_codes
+		SET consumed_at = $3
+		WHERE user_id = $1 AND purpose = $2 AND consumed_at IS NULL
+	`
+	if _, err := db.Exec(ctx, query, userID, purpose, at); err != nil {
+		return fmt.Errorf("failed to invalidate verification codes: %w", err)
+	}
+	return nil
+}
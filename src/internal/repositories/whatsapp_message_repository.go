@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WhatsAppMessageRepository defines the interface for the outbound WhatsApp message log
+type WhatsAppMessageRepository interface {
+	Create(ctx context.Context, message *models.WhatsAppMessage) error
+	ApplyStatus(ctx context.Context, update *models.WhatsAppStatusUpdate) error
+	FindByID(ctx context.Context, id string) (*models.WhatsAppMessage, error)
+	Link(ctx context.Context, id string, businessID, ticketID *uuid.UUID) error
+	FindByBusiness(ctx context.Context, businessID uuid.UUID, ticketID *uuid.UUID, limit int) ([]*models.WhatsAppMessage, error)
+}
+
+// whatsappMessageRepository implements WhatsAppMessageRepository
+type whatsappMessageRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWhatsAppMessageRepository creates a new instance of WhatsAppMessageRepository
+func NewWhatsAppMessageRepository(pool *pgxpool.Pool) WhatsAppMessageRepository {
+	return &whatsappMessageRepository{
+		pool: pool,
+	}
+}
+
+const whatsappMessageColumns = `id, recipient, business_id, ticket_id, type, template_name, status, error_code, error_title,
+	sent_at, delivered_at, read_at, failed_at, created_at, updated_at`
+
+// scanWhatsAppMessage scans a single WhatsApp message row
+func scanWhatsAppMessage(row pgx.Row) (*models.WhatsAppMessage, error) {
+	message := &models.WhatsAppMessage{}
+	err := row.Scan(
+		&message.ID,
+		&message.Recipient,
+		&message.BusinessID,
+		&message.TicketID,
+		&message.Type,
+		&message.TemplateName,
+		&message.Status,
+		&message.ErrorCode,
+		&message.ErrorTitle,
+		&message.SentAt,
+		&message.DeliveredAt,
+		&message.ReadAt,
+		&message.FailedAt,
+		&message.CreatedAt,
+		&message.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// Create stores a message accepted by the Graph API.
+// A status callback may arrive before the message is stored; the status it left is kept.
+func (r *whatsappMessageRepository) Create(ctx context.Context, message *models.WhatsAppMessage) error {
+	query := `
+		INSERT INTO whatsapp_messages (id, recipient, type, template_name, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE SET type = EXCLUDED.type, template_name = EXCLUDED.template_name
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		message.ID,
+		message.Recipient,
+		message.Type,
+		message.TemplateName,
+		message.Status,
+		message.CreatedAt,
+		message.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create whatsapp message: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyStatus records a delivery status of a message.
+// Meta does not guarantee the order of status callbacks, so the status only moves forward
+// (accepted, sent, delivered, read) and failed is final; the time of every status is kept
+// even when it arrives late. Unknown messages are created from the callback.
+func (r *whatsappMessageRepository) ApplyStatus(ctx context.Context, update *models.WhatsAppStatusUpdate) error {
+	var sentAt, deliveredAt, readAt, failedAt interface{}
+	switch update.Status {
+	case models.WhatsAppMessageStatusSent:
+		sentAt = update.Timestamp
+	case models.WhatsAppMessageStatusDelivered:
+		deliveredAt = update.Timestamp
+	case models.WhatsAppMessageStatusRead:
+		readAt = update.Timestamp
+	case models.WhatsAppMessageStatusFailed:
+		failedAt = update.Timestamp
+	default:
+		return fmt.Errorf("invalid whatsapp message status: %s", update.Status)
+	}
+
+	query := `
+		INSERT INTO whatsapp_messages (id, recipient, status, error_code, error_title, sent_at, delivered_at, read_at, failed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			status = CASE
+				WHEN whatsapp_messages.status = 'failed' THEN whatsapp_messages.status
+				WHEN EXCLUDED.status = 'failed' THEN EXCLUDED.status
+				WHEN array_position(ARRAY['accepted', 'sent', 'delivered', 'read'], EXCLUDED.status::TEXT)
+					> array_position(ARRAY['accepted', 'sent', 'delivered', 'read'], whatsapp_messages.status::TEXT) THEN EXCLUDED.status
+				ELSE whatsapp_messages.status
+			END,
+			error_code = COALESCE(EXCLUDED.error_code, whatsapp_messages.error_code),
+			error_title = COALESCE(EXCLUDED.error_title, whatsapp_messages.error_title),
+			sent_at = COALESCE(whatsapp_messages.sent_at, EXCLUDED.sent_at),
+			delivered_at = COALESCE(whatsapp_messages.delivered_at, EXCLUDED.delivered_at),
+			read_at = COALESCE(whatsapp_messages.read_at, EXCLUDED.read_at),
+			failed_at = COALESCE(whatsapp_messages.failed_at, EXCLUDED.failed_at),
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		update.MessageID,
+		update.Recipient,
+		update.Status,
+		update.ErrorCode,
+		update.ErrorTitle,
+		sentAt,
+		deliveredAt,
+		readAt,
+		failedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to apply whatsapp message status: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a message by the ID returned by the Graph API
+func (r *whatsappMessageRepository) FindByID(ctx context.Context, id string) (*models.WhatsAppMessage, error) {
+	query := `SELECT ` + whatsappMessageColumns + ` FROM whatsapp_messages WHERE id = $1`
+
+	message, err := scanWhatsAppMessage(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("whatsapp message not found")
+		}
+		return nil, fmt.Errorf("failed to find whatsapp message: %w", err)
+	}
+
+	return message, nil
+}
+
+// Link records the business and ticket a stored message is about
+func (r *whatsappMessageRepository) Link(ctx context.Context, id string, businessID, ticketID *uuid.UUID) error {
+	query := `UPDATE whatsapp_messages SET business_id = $2, ticket_id = $3 WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, id, businessID, ticketID)
+	if err != nil {
+		return fmt.Errorf("failed to link whatsapp message: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("whatsapp message not found")
+	}
+
+	return nil
+}
+
+// FindByBusiness returns the latest messages about a business, newest first,
+// optionally only those about one of its tickets
+func (r *whatsappMessageRepository) FindByBusiness(ctx context.Context, businessID uuid.UUID, ticketID *uuid.UUID, limit int) ([]*models.WhatsAppMessage, error) {
+	query := `
+		SELECT ` + whatsappMessageColumns + `
+		FROM whatsapp_messages
+		WHERE business_id = $1 AND ($2::UUID IS NULL OR ticket_id = $2)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, businessID, ticketID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query whatsapp messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]*models.WhatsAppMessage, 0)
+	for rows.Next() {
+		message, err := scanWhatsAppMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan whatsapp message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating whatsapp messages: %w", err)
+	}
+
+	return messages, nil
+}
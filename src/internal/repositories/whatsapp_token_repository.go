@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WhatsAppTokenRepository defines the interface for stored WhatsApp access tokens
+type WhatsAppTokenRepository interface {
+	Find(ctx context.Context, phoneNumberID string) (*models.WhatsAppAccessToken, error)
+	Save(ctx context.Context, token *models.WhatsAppAccessToken) error
+}
+
+// whatsappTokenRepository implements WhatsAppTokenRepository
+type whatsappTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWhatsAppTokenRepository creates a new instance of WhatsAppTokenRepository
+func NewWhatsAppTokenRepository(pool *pgxpool.Pool) WhatsAppTokenRepository {
+	return &whatsappTokenRepository{
+		pool: pool,
+	}
+}
+
+// Find returns the stored access token of a phone number
+func (r *whatsappTokenRepository) Find(ctx context.Context, phoneNumberID string) (*models.WhatsAppAccessToken, error) {
+	query := `
+		SELECT phone_number_id, access_token, seed_token_hash, expires_at, created_at, updated_at
+		FROM whatsapp_access_tokens
+		WHERE phone_number_id = $1
+	`
+
+	token := &models.WhatsAppAccessToken{}
+	err := r.pool.QueryRow(ctx, query, phoneNumberID).Scan(
+		&token.PhoneNumberID,
+		&token.AccessToken,
+		&token.SeedTokenHash,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("whatsapp token not found")
+		}
+		return nil, fmt.Errorf("failed to find whatsapp token: %w", err)
+	}
+
+	return token, nil
+}
+
+// Save stores the access token of a phone number, replacing the previous one
+func (r *whatsappTokenRepository) Save(ctx context.Context, token *models.WhatsAppAccessToken) error {
+	query := `
+		INSERT INTO whatsapp_access_tokens (phone_number_id, access_token, seed_token_hash, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (phone_number_id) DO UPDATE SET
+			access_token = EXCLUDED.access_token,
+			seed_token_hash = EXCLUDED.seed_token_hash,
+			expires_at = EXCLUDED.expires_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		token.PhoneNumberID,
+		token.AccessToken,
+		token.SeedTokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+		token.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save whatsapp token: %w", err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"easy-queue-go/src/internal/middleware"
 	"easy-queue-go/src/internal/models"
 	"easy-queue-go/src/internal/services"
+	"fmt"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -19,10 +20,22 @@ func SetupRouter(
 	userHandler *handlers.UserHandler,
 	authHandler *handlers.AuthHandler,
 	businessHandler *handlers.BusinessHandler,
+	queueHandler *handlers.QueueHandler,
+	ticketHandler *handlers.TicketHandler,
 	whatsappHandler *handlers.WhatsAppHandler,
 	authService services.AuthService,
-) *gin.Engine {
-	router := gin.Default()
+	authz services.AuthorizationService,
+	trustedProxies []string,
+) (*gin.Engine, error) {
+	router, err := newEngine(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	// businessPermission checks a permission on the business in the :id path parameter
+	businessPermission := func(permission models.Permission) gin.HandlerFunc {
+		return middleware.RequireBusinessPermission(authz, permission, "id")
+	}
 
 	// Add logger middleware with request-id
 	router.Use(middleware.LoggerMiddleware())
@@ -36,11 +49,17 @@ func SetupRouter(
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Token verification keys (public)
+	router.GET("/.well-known/jwks.json", authHandler.GetJWKS)
+
 	// Auth routes (public)
 	authGroup := router.Group("/auth")
 	{
 		authGroup.POST("/login", authHandler.Login)
 		authGroup.POST("/refresh", authHandler.RefreshToken)
+		authGroup.POST("/logout", authHandler.Logout)
+		authGroup.POST("/password-reset/request", authHandler.RequestPasswordReset)
+		authGroup.POST("/password-reset/confirm", authHandler.ConfirmPasswordReset)
 	}
 
 	// User registration (public)
@@ -57,42 +76,131 @@ func SetupRouter(
 	protected := router.Group("")
 	protected.Use(middleware.AuthMiddleware(authService))
 	{
+		// Session routes
+		protected.POST("/auth/logout-all", authHandler.LogoutAll)
+
 		// User routes (authenticated users can access their own data)
 		usersGroup := protected.Group("/users")
 		{
 			usersGroup.GET("/me", userHandler.GetMyProfile)
+			usersGroup.PATCH("/me", userHandler.UpdateMyProfile)
+			usersGroup.POST("/me/password", authHandler.ChangePassword)
+			usersGroup.POST("/me/phone/verification", userHandler.SendPhoneVerification)
+			usersGroup.POST("/me/phone/verify", userHandler.VerifyPhone)
 		}
 
-		// Business routes (authenticated users)
+		// Business routes, authorized per route against the roles of the user and their
+		// role in the business (owner, manager or staff). Customers may view any business and its queues.
 		businessGroup := protected.Group("/businesses")
-		businessGroup.Use(middleware.RequireRole(models.RoleBusinessOwner))
 		{
-			businessGroup.POST("", businessHandler.CreateBusiness)
-			businessGroup.GET("/my", businessHandler.GetMyBusinesses)
-			businessGroup.GET("/:id", businessHandler.GetBusinessByID)
-			businessGroup.PUT("/:id", businessHandler.UpdateBusiness)
-			businessGroup.DELETE("/:id", businessHandler.DeleteBusiness)
+			businessGroup.POST("", middleware.RequirePermission(authz, models.PermissionBusinessCreate), businessHandler.CreateBusiness)
+			businessGroup.GET("/my", middleware.RequirePermission(authz, models.PermissionBusinessListOwn), businessHandler.GetMyBusinesses)
+			businessGroup.GET("/:id", businessPermission(models.PermissionBusinessView), businessHandler.GetBusinessByID)
+			businessGroup.PUT("/:id", businessPermission(models.PermissionBusinessUpdate), businessHandler.UpdateBusiness)
+			businessGroup.DELETE("/:id", businessPermission(models.PermissionBusinessDelete), businessHandler.DeleteBusiness)
+
+			// Member management for the business
+			businessGroup.GET("/:id/members", businessPermission(models.PermissionBusinessManageMembers), businessHandler.GetMembers)
+			businessGroup.PUT("/:id/members/:userId", businessPermission(models.PermissionBusinessManageMembers), businessHandler.SetMember)
+			businessGroup.DELETE("/:id/members/:userId", businessPermission(models.PermissionBusinessManageMembers), businessHandler.RemoveMember)
+
+			// Service catalog management for the business
+			businessGroup.POST("/:id/services", businessPermission(models.PermissionCatalogManage), businessHandler.CreateServiceOffering)
+			businessGroup.PUT("/:id/services/:serviceId", businessPermission(models.PermissionCatalogManage), businessHandler.UpdateServiceOffering)
+			businessGroup.DELETE("/:id/services/:serviceId", businessPermission(models.PermissionCatalogManage), businessHandler.DeleteServiceOffering)
+
+			// Opening hours management for the business
+			businessGroup.PUT("/:id/opening-hours", businessPermission(models.PermissionScheduleManage), businessHandler.SetOpeningHours)
+			businessGroup.POST("/:id/closures", businessPermission(models.PermissionScheduleManage), businessHandler.CreateClosure)
+			businessGroup.DELETE("/:id/closures/:closureId", businessPermission(models.PermissionScheduleManage), businessHandler.DeleteClosure)
+			businessGroup.PUT("/:id/cancellation-policy", businessPermission(models.PermissionScheduleManage), businessHandler.SetCancellationPolicy)
+
+			// Queue management for the business
+			businessGroup.POST("/:id/queues", businessPermission(models.PermissionQueueManage), queueHandler.CreateQueue)
+			businessGroup.GET("/:id/queues", businessPermission(models.PermissionQueueView), queueHandler.GetQueues)
+			businessGroup.GET("/:id/queues/:queueId", businessPermission(models.PermissionQueueView), queueHandler.GetQueueByID)
+			businessGroup.PUT("/:id/queues/:queueId", businessPermission(models.PermissionQueueManage), queueHandler.UpdateQueue)
+			businessGroup.DELETE("/:id/queues/:queueId", businessPermission(models.PermissionQueueManage), queueHandler.DeleteQueue)
+			businessGroup.GET("/:id/queues/:queueId/tickets", businessPermission(models.PermissionQueueViewTickets), queueHandler.ListQueueTickets)
+			businessGroup.GET("/:id/whatsapp-messages", businessPermission(models.PermissionQueueViewTickets), whatsappHandler.ListBusinessMessages)
+
+			// Queue console actions
+			businessGroup.POST("/:id/queues/:queueId/call-next", businessPermission(models.PermissionQueueCallNext), queueHandler.CallNextTicket)
+			businessGroup.POST("/:id/queues/:queueId/tickets/:ticketId/skip", businessPermission(models.PermissionQueueCallNext), queueHandler.SkipTicket)
+			businessGroup.POST("/:id/queues/:queueId/tickets/:ticketId/recall", businessPermission(models.PermissionQueueCallNext), queueHandler.RecallTicket)
+			businessGroup.POST("/:id/queues/:queueId/tickets/:ticketId/start", businessPermission(models.PermissionQueueCallNext), queueHandler.StartTicket)
+			businessGroup.POST("/:id/queues/:queueId/tickets/:ticketId/complete", businessPermission(models.PermissionQueueCallNext), queueHandler.CompleteTicket)
+		}
+
+		// Service catalog, opening hours and policies (any authenticated user can browse them)
+		catalogGroup := protected.Group("/businesses/:id/services")
+		{
+			catalogGroup.GET("", businessHandler.GetServiceOfferings)
+			catalogGroup.GET("/:serviceId", businessHandler.GetServiceOfferingByID)
+		}
+		protected.GET("/businesses/:id/opening-hours", businessHandler.GetOpeningHours)
+		protected.GET("/businesses/:id/closures", businessHandler.GetClosures)
+		protected.GET("/businesses/:id/cancellation-policy", businessHandler.GetCancellationPolicy)
+
+		// Customer queue routes
+		queuesGroup := protected.Group("/queues")
+		queuesGroup.Use(middleware.RequirePermission(authz, models.PermissionTicketJoin))
+		{
+			queuesGroup.POST("/:queueId/tickets", ticketHandler.JoinQueue)
+			queuesGroup.GET("/:queueId/availability", ticketHandler.GetAvailability)
+			queuesGroup.POST("/:queueId/appointments", ticketHandler.BookAppointment)
+		}
+
+		// Customer ticket routes
+		ticketsGroup := protected.Group("/tickets")
+		ticketsGroup.Use(middleware.RequirePermission(authz, models.PermissionTicketManageOwn))
+		{
+			ticketsGroup.GET("/my", ticketHandler.GetMyTickets)
+			ticketsGroup.GET("/:ticketId/position", ticketHandler.GetTicketPosition)
+			ticketsGroup.POST("/:ticketId/check-in", ticketHandler.CheckIn)
+			ticketsGroup.POST("/:ticketId/cancel", ticketHandler.CancelTicket)
+			ticketsGroup.POST("/:ticketId/reschedule", ticketHandler.RescheduleTicket)
 		}
 
 		// Admin-only routes
 		adminGroup := protected.Group("/admin")
-		adminGroup.Use(middleware.RequireRole(models.RoleAdmin))
 		{
-			adminGroup.GET("/users", userHandler.ListAllUsers)
-			adminGroup.GET("/businesses", businessHandler.ListAllBusinesses)
+			adminGroup.GET("/users", middleware.RequirePermission(authz, models.PermissionUserList), userHandler.ListAllUsers)
+			adminGroup.POST("/users", middleware.RequirePermission(authz, models.PermissionUserCreate), userHandler.CreateUserAsAdmin)
+			adminGroup.POST("/users/:id/deactivate", middleware.RequirePermission(authz, models.PermissionUserDeactivate), userHandler.DeactivateUser)
+			adminGroup.POST("/users/:id/reactivate", middleware.RequirePermission(authz, models.PermissionUserDeactivate), userHandler.ReactivateUser)
+			adminGroup.PUT("/users/:id/roles/:role", middleware.RequirePermission(authz, models.PermissionUserChangeRoles), userHandler.AddUserRole)
+			adminGroup.DELETE("/users/:id/roles/:role", middleware.RequirePermission(authz, models.PermissionUserChangeRoles), userHandler.RemoveUserRole)
+			adminGroup.DELETE("/users/:id", middleware.RequirePermission(authz, models.PermissionUserDelete), userHandler.DeleteUser)
+			adminGroup.GET("/businesses", middleware.RequirePermission(authz, models.PermissionBusinessListAll), businessHandler.ListAllBusinesses)
 		}
 
 		// Debug routes (authenticated users - for testing)
 		debugGroup := protected.Group("/debug")
-		debugGroup.Use(middleware.RequireRole(models.RoleAdmin))
+		debugGroup.Use(middleware.RequirePermission(authz, models.PermissionSystemDebug))
 		{
 			// WhatsApp debug endpoints
 			debugGroup.GET("/whatsapp/status", whatsappHandler.GetStatus)
 			debugGroup.POST("/whatsapp/send", whatsappHandler.SendMessage)
 			debugGroup.POST("/whatsapp/send-text", whatsappHandler.SendTextMessage)
 			debugGroup.POST("/whatsapp/send-template", whatsappHandler.SendTemplateMessage)
+			debugGroup.GET("/whatsapp/messages/:messageId", whatsappHandler.GetMessage)
 		}
 	}
 
-	return router
+	return router, nil
+}
+
+// newEngine creates the gin engine with the default middleware, trusting X-Forwarded-For only from the given
+// proxies. Without trusted proxies the client IP is the remote address, so clients cannot spoof it to dodge
+// the per-IP login throttle.
+func newEngine(trustedProxies []string) (*gin.Engine, error) {
+	router := gin.Default()
+	if len(trustedProxies) == 0 {
+		trustedProxies = nil
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, fmt.Errorf("failed to set trusted proxies: %w", err)
+	}
+	return router, nil
 }
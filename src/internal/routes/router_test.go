@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"easy-queue-go/src/internal/handlers"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/services"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeAuthService counts failed logins per client IP, the key of the per-IP login throttle
+type fakeAuthService struct {
+	services.AuthService
+	failures map[string]int
+}
+
+func (s *fakeAuthService) Login(ctx context.Context, req *models.LoginRequest, clientIP string) (*models.LoginResponse, error) {
+	s.failures[clientIP]++
+	return nil, fmt.Errorf("invalid credentials")
+}
+
+// postLogins sends a failed login from remoteAddr for each X-Forwarded-For value,
+// through an engine that trusts the given proxies
+func postLogins(t *testing.T, trustedProxies []string, remoteAddr string, forwardedFor ...string) map[string]int {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router, err := newEngine(trustedProxies)
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	authService := &fakeAuthService{failures: map[string]int{}}
+	router.POST("/auth/login", handlers.NewAuthHandler(authService, nil).Login)
+
+	for _, header := range forwardedFor {
+		body := []byte(`{"email":"user@example.com","password":"wrong-password"}`)
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(body))
+		req = req.WithContext(log.Initialize(context.Background()))
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", header)
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusUnauthorized, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	return authService.failures
+}
+
+func TestLoginThrottleIgnoresForwardedForFromUntrustedClients(t *testing.T) {
+	failures := postLogins(t, nil, "198.51.100.7:4321", "203.0.113.1", "203.0.113.2", "203.0.113.3")
+
+	if len(failures) != 1 || failures["198.51.100.7"] != 3 {
+		t.Fatalf("expected 3 failures counted for the remote address, got %v", failures)
+	}
+}
+
+func TestLoginThrottleUsesForwardedForFromTrustedProxies(t *testing.T) {
+	failures := postLogins(t, []string{"10.0.0.0/8"}, "10.0.0.5:4321", "203.0.113.1", "203.0.113.1")
+
+	if len(failures) != 1 || failures["203.0.113.1"] != 2 {
+		t.Fatalf("expected 2 failures counted for the forwarded client IP, got %v", failures)
+	}
+}
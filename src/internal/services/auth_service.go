@@ -18,52 +18,69 @@ import (
 
 // AuthService defines the interface for authentication operations
 type AuthService interface {
-	Login(ctx context.Context, req *models.LoginRequest) (*models.LoginResponse, error)
+	Login(ctx context.Context, req *models.LoginRequest, clientIP string) (*models.LoginResponse, error)
 	RefreshToken(ctx context.Context, req *models.RefreshTokenRequest) (*models.RefreshTokenResponse, error)
 	ValidateToken(ctx context.Context, tokenString string, tokenType models.TokenType) (*models.JWTClaims, error)
+	Logout(ctx context.Context, req *models.LogoutRequest) error
+	LogoutAll(ctx context.Context, userID uuid.UUID) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, req *models.ChangePasswordRequest, clientIP string) (*models.LoginResponse, error)
+	JWKS() *models.JSONWebKeySet
 }
 
 // authService implements AuthService
 type authService struct {
 	userRepo         repositories.UserRepository
-	jwtSecret        string
+	refreshTokenRepo repositories.RefreshTokenRepository
+	loginThrottle    LoginThrottleService
+	keys             *TokenKeySet
 	accessTokenTTL   time.Duration
 	refreshTokenTTL  time.Duration
 }
 
 // AuthServiceConfig holds configuration for the auth service
 type AuthServiceConfig struct {
-	JWTSecret       string
+	Keys            *TokenKeySet
 	AccessTokenTTL  time.Duration
 	RefreshTokenTTL time.Duration
 }
 
 // NewAuthService creates a new instance of AuthService
-func NewAuthService(userRepo repositories.UserRepository, config AuthServiceConfig) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, refreshTokenRepo repositories.RefreshTokenRepository, loginThrottle LoginThrottleService, config AuthServiceConfig) AuthService {
 	return &authService{
-		userRepo:        userRepo,
-		jwtSecret:       config.JWTSecret,
-		accessTokenTTL:  config.AccessTokenTTL,
-		refreshTokenTTL: config.RefreshTokenTTL,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		loginThrottle:    loginThrottle,
+		keys:             config.Keys,
+		accessTokenTTL:   config.AccessTokenTTL,
+		refreshTokenTTL:  config.RefreshTokenTTL,
 	}
 }
 
-// Login authenticates a user and returns JWT tokens
-func (s *authService) Login(ctx context.Context, req *models.LoginRequest) (*models.LoginResponse, error) {
+// Login authenticates a user and returns JWT tokens.
+// Failed attempts are throttled per email and per client IP; a blocked login returns a *LoginThrottledError.
+func (s *authService) Login(ctx context.Context, req *models.LoginRequest, clientIP string) (*models.LoginResponse, error) {
 	ctx, span := tracer.Start(ctx, "AuthService.Login",
 		trace.WithAttributes(
 			attribute.String("email", req.Email),
+			attribute.String("client_ip", clientIP),
 		),
 	)
 	defer span.End()
 
 	log.Info(ctx, "User login attempt", zap.String("email", req.Email))
 
+	// Count the attempt and refuse it before checking the password if there were too many
+	if err := s.loginThrottle.ReserveAttempt(ctx, req.Email, clientIP); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	// Find user by email
 	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
 		log.Warn(ctx, "Login failed: user not found", zap.String("email", req.Email))
 		span.RecordError(err)
+		s.loginThrottle.RecordFailure(ctx, req.Email, clientIP)
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
@@ -79,23 +96,16 @@ func (s *authService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
 		log.Warn(ctx, "Login failed: invalid password", zap.String("email", req.Email))
+		s.loginThrottle.RecordFailure(ctx, req.Email, clientIP)
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
-	// Generate access token
-	accessToken, err := s.generateToken(user, models.TokenTypeAccess, s.accessTokenTTL)
-	if err != nil {
-		log.Error(ctx, "Failed to generate access token", zap.Error(err))
-		span.RecordError(err)
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
+	s.loginThrottle.RecordSuccess(ctx, req.Email, clientIP)
 
-	// Generate refresh token
-	refreshToken, err := s.generateToken(user, models.TokenTypeRefresh, s.refreshTokenTTL)
+	response, err := s.startSession(ctx, user)
 	if err != nil {
-		log.Error(ctx, "Failed to generate refresh token", zap.Error(err))
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, err
 	}
 
 	log.Info(ctx, "User logged in successfully",
@@ -105,16 +115,11 @@ func (s *authService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 
 	span.SetAttributes(attribute.String("user_id", user.ID.String()))
 
-	return &models.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    int64(s.accessTokenTTL.Seconds()),
-		User:         user.ToResponse(),
-	}, nil
+	return response, nil
 }
 
-// RefreshToken generates new access and refresh tokens using a valid refresh token
+// RefreshToken generates new access and refresh tokens using a valid refresh token.
+// Each refresh token can be used once: presenting it again revokes every token of its family.
 func (s *authService) RefreshToken(ctx context.Context, req *models.RefreshTokenRequest) (*models.RefreshTokenResponse, error) {
 	ctx, span := tracer.Start(ctx, "AuthService.RefreshToken")
 	defer span.End()
@@ -129,6 +134,13 @@ func (s *authService) RefreshToken(ctx context.Context, req *models.RefreshToken
 		return nil, fmt.Errorf("invalid refresh token")
 	}
 
+	usedID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		log.Warn(ctx, "Token refresh failed: refresh token has no valid jti")
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid refresh token")
+	}
+
 	// Get user from database to ensure they still exist and are active
 	user, err := s.userRepo.FindByID(ctx, claims.UserID)
 	if err != nil {
@@ -154,14 +166,32 @@ func (s *authService) RefreshToken(ctx context.Context, req *models.RefreshToken
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	// Generate new refresh token
-	refreshToken, err := s.generateToken(user, models.TokenTypeRefresh, s.refreshTokenTTL)
+	// Rotate the refresh token: the new one joins the family of the one being used
+	next := models.NewRefreshToken(user.ID, s.refreshTokenTTL)
+	refreshToken, err := s.signToken(user, models.TokenTypeRefresh, next.ID, next.CreatedAt, next.ExpiresAt)
 	if err != nil {
 		log.Error(ctx, "Failed to generate new refresh token", zap.Error(err))
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
+	if err := s.refreshTokenRepo.Rotate(ctx, usedID, next); err != nil {
+		span.RecordError(err)
+		switch err.Error() {
+		case "refresh token reuse detected":
+			log.Warn(ctx, "Token refresh failed: refresh token reuse detected, token family revoked",
+				zap.String("user_id", user.ID.String()),
+				zap.String("jti", usedID.String()),
+			)
+			return nil, err
+		case "refresh token not found", "refresh token revoked":
+			log.Warn(ctx, "Token refresh failed: refresh token is not valid", zap.Error(err))
+			return nil, fmt.Errorf("invalid refresh token")
+		}
+		log.Error(ctx, "Failed to rotate refresh token", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
 	log.Info(ctx, "Tokens refreshed successfully",
 		zap.String("user_id", user.ID.String()),
 	)
@@ -178,7 +208,7 @@ func (s *authService) RefreshToken(ctx context.Context, req *models.RefreshToken
 
 // ValidateToken validates a JWT token and returns its claims
 func (s *authService) ValidateToken(ctx context.Context, tokenString string, expectedType models.TokenType) (*models.JWTClaims, error) {
-	_, span := tracer.Start(ctx, "AuthService.ValidateToken",
+	ctx, span := tracer.Start(ctx, "AuthService.ValidateToken",
 		trace.WithAttributes(
 			attribute.String("token_type", string(expectedType)),
 		),
@@ -186,13 +216,7 @@ func (s *authService) ValidateToken(ctx context.Context, tokenString string, exp
 	defer span.End()
 
 	// Parse token
-	token, err := jwt.ParseWithClaims(tokenString, &models.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.jwtSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &models.JWTClaims{}, s.keys.Keyfunc)
 
 	if err != nil {
 		span.RecordError(err)
@@ -214,16 +238,195 @@ func (s *authService) ValidateToken(ctx context.Context, tokenString string, exp
 		return nil, err
 	}
 
+	// Reject tokens issued before the user logged out of all devices
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to load token user: %w", err)
+	}
+
+	if claims.IssuedAt == nil || !user.AcceptsTokenIssuedAt(claims.IssuedAt.Time) {
+		err := fmt.Errorf("token has been revoked")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if !user.IsActive {
+		err := fmt.Errorf("user account is inactive")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Authorize with the current roles rather than the ones granted when the token was issued
+	claims.Roles = user.Roles
+	claims.Email = user.Email
+
 	span.SetAttributes(attribute.String("user_id", claims.UserID.String()))
 
 	return claims, nil
 }
 
+// Logout ends the session of a refresh token by revoking it and every token rotated from the same login.
+// Access tokens already issued for the session stay valid until they expire.
+func (s *authService) Logout(ctx context.Context, req *models.LogoutRequest) error {
+	ctx, span := tracer.Start(ctx, "AuthService.Logout")
+	defer span.End()
+
+	claims, err := s.ValidateToken(ctx, req.RefreshToken, models.TokenTypeRefresh)
+	if err != nil {
+		log.Warn(ctx, "Logout failed: invalid refresh token", zap.Error(err))
+		span.RecordError(err)
+		return fmt.Errorf("invalid refresh token")
+	}
+
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		log.Warn(ctx, "Logout failed: refresh token has no valid jti")
+		span.RecordError(err)
+		return fmt.Errorf("invalid refresh token")
+	}
+
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, tokenID, claims.UserID); err != nil {
+		log.Error(ctx, "Failed to revoke refresh token", zap.Error(err), zap.String("user_id", claims.UserID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	log.Info(ctx, "User logged out", zap.String("user_id", claims.UserID.String()))
+
+	span.SetAttributes(attribute.String("user_id", claims.UserID.String()))
+
+	return nil
+}
+
+// LogoutAll ends every session of a user: refresh tokens are revoked and access tokens
+// issued until now are rejected
+func (s *authService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "AuthService.LogoutAll",
+		trace.WithAttributes(
+			attribute.String("user_id", userID.String()),
+		),
+	)
+	defer span.End()
+
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		log.Error(ctx, "Failed to revoke refresh tokens", zap.Error(err), zap.String("user_id", userID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.userRepo.InvalidateTokens(ctx, userID, time.Now()); err != nil {
+		log.Error(ctx, "Failed to invalidate access tokens", zap.Error(err), zap.String("user_id", userID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	log.Info(ctx, "User logged out of all devices", zap.String("user_id", userID.String()))
+
+	return nil
+}
+
+// ChangePassword sets a new password after checking the current one. Every other session of the user
+// is ended, and a new session is returned in place of the one that made the change.
+// Wrong current passwords count as failed logins, so a stolen access token cannot be used to guess the password.
+func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, req *models.ChangePasswordRequest, clientIP string) (*models.LoginResponse, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.ChangePassword",
+		trace.WithAttributes(
+			attribute.String("user_id", userID.String()),
+		),
+	)
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.loginThrottle.ReserveAttempt(ctx, user.Email, clientIP); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+		log.Warn(ctx, "Password change failed: wrong current password", zap.String("user_id", user.ID.String()))
+		s.loginThrottle.RecordFailure(ctx, user.Email, clientIP)
+		return nil, fmt.Errorf("current password is incorrect")
+	}
+
+	s.loginThrottle.RecordSuccess(ctx, user.Email, clientIP)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error(ctx, "Failed to hash password", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to process password: %w", err)
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, string(hashedPassword), time.Now()); err != nil {
+		log.Error(ctx, "Failed to store new password", zap.Error(err), zap.String("user_id", user.ID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.LogoutAll(ctx, user.ID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	response, err := s.startSession(ctx, user)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Password changed", zap.String("user_id", user.ID.String()))
+
+	return response, nil
+}
+
+// JWKS returns the public keys that verify the tokens issued by the service
+func (s *authService) JWKS() *models.JSONWebKeySet {
+	return s.keys.JWKS()
+}
+
+// startSession issues an access token and a refresh token starting a new token family
+func (s *authService) startSession(ctx context.Context, user *models.User) (*models.LoginResponse, error) {
+	accessToken, err := s.generateToken(user, models.TokenTypeAccess, s.accessTokenTTL)
+	if err != nil {
+		log.Error(ctx, "Failed to generate access token", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	record := models.NewRefreshToken(user.ID, s.refreshTokenTTL)
+	refreshToken, err := s.signToken(user, models.TokenTypeRefresh, record.ID, record.CreatedAt, record.ExpiresAt)
+	if err != nil {
+		log.Error(ctx, "Failed to generate refresh token", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.Create(ctx, record); err != nil {
+		log.Error(ctx, "Failed to store refresh token", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &models.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.accessTokenTTL.Seconds()),
+		User:         user.ToResponse(),
+	}, nil
+}
+
 // generateToken creates a new JWT token for a user
 func (s *authService) generateToken(user *models.User, tokenType models.TokenType, ttl time.Duration) (string, error) {
 	now := time.Now()
-	expiresAt := now.Add(ttl)
+	return s.signToken(user, tokenType, uuid.New(), now, now.Add(ttl))
+}
 
+// signToken creates a JWT token for a user with the given JWT ID and validity
+func (s *authService) signToken(user *models.User, tokenType models.TokenType, jti uuid.UUID, now, expiresAt time.Time) (string, error) {
 	claims := &models.JWTClaims{
 		UserID: user.ID,
 		Email:  user.Email,
@@ -235,12 +438,11 @@ func (s *authService) generateToken(user *models.User, tokenType models.TokenTyp
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "easy-queue-go",
 			Subject:   user.ID.String(),
-			ID:        uuid.New().String(), // Unique token ID (jti)
+			ID:        jti.String(), // Unique token ID (jti)
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(s.jwtSecret))
+	signedToken, err := s.keys.Sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// CreateServiceOffering adds a service to the catalog of a business
+func (s *businessService) CreateServiceOffering(ctx context.Context, businessID uuid.UUID, req *models.CreateServiceOfferingRequest) (*models.ServiceOfferingResponse, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.CreateServiceOffering",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Creating service offering",
+		zap.String("business_id", businessID.String()),
+		zap.String("name", req.Name),
+	)
+
+	offering := req.ToServiceOffering(businessID)
+
+	if err := s.offeringRepo.Create(ctx, offering); err != nil {
+		log.Error(ctx, "Failed to create service offering in database",
+			zap.Error(err),
+			zap.String("business_id", businessID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Service offering created successfully",
+		zap.String("service_id", offering.ID.String()),
+		zap.String("business_id", businessID.String()),
+	)
+
+	span.SetAttributes(attribute.String("service_id", offering.ID.String()))
+
+	return offering.ToResponse(), nil
+}
+
+// GetServiceOfferings lists the service catalog of a business
+func (s *businessService) GetServiceOfferings(ctx context.Context, businessID uuid.UUID) ([]*models.ServiceOfferingResponse, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.GetServiceOfferings",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	if _, err := s.businessRepo.FindByID(ctx, businessID); err != nil {
+		log.Error(ctx, "Failed to find business", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	offerings, err := s.offeringRepo.FindByBusinessID(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to get service offerings",
+			zap.Error(err),
+			zap.String("business_id", businessID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	responses := make([]*models.ServiceOfferingResponse, len(offerings))
+	for i, offering := range offerings {
+		responses[i] = offering.ToResponse()
+	}
+
+	span.SetAttributes(attribute.Int("service_count", len(responses)))
+
+	return responses, nil
+}
+
+// GetServiceOfferingByID retrieves a service of a business catalog
+func (s *businessService) GetServiceOfferingByID(ctx context.Context, businessID, serviceID uuid.UUID) (*models.ServiceOfferingResponse, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.GetServiceOfferingByID",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("service_id", serviceID.String()),
+		),
+	)
+	defer span.End()
+
+	offering, err := s.getBusinessServiceOffering(ctx, businessID, serviceID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return offering.ToResponse(), nil
+}
+
+// UpdateServiceOffering updates a service of a business
+func (s *businessService) UpdateServiceOffering(ctx context.Context, businessID, serviceID uuid.UUID, req *models.UpdateServiceOfferingRequest) (*models.ServiceOfferingResponse, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.UpdateServiceOffering",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("service_id", serviceID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Updating service offering",
+		zap.String("business_id", businessID.String()),
+		zap.String("service_id", serviceID.String()),
+	)
+
+	offering, err := s.getBusinessServiceOffering(ctx, businessID, serviceID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	offering.ApplyUpdateRequest(req)
+
+	if err := s.offeringRepo.Update(ctx, offering); err != nil {
+		log.Error(ctx, "Failed to update service offering in database",
+			zap.Error(err),
+			zap.String("service_id", serviceID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Service offering updated successfully", zap.String("service_id", serviceID.String()))
+
+	return offering.ToResponse(), nil
+}
+
+// DeleteServiceOffering removes a service from the catalog of a business
+func (s *businessService) DeleteServiceOffering(ctx context.Context, businessID, serviceID uuid.UUID) error {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.DeleteServiceOffering",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("service_id", serviceID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Deleting service offering",
+		zap.String("business_id", businessID.String()),
+		zap.String("service_id", serviceID.String()),
+	)
+
+	if _, err := s.getBusinessServiceOffering(ctx, businessID, serviceID); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.offeringRepo.Delete(ctx, serviceID); err != nil {
+		log.Error(ctx, "Failed to delete service offering from database",
+			zap.Error(err),
+			zap.String("service_id", serviceID.String()),
+		)
+		span.RecordError(err)
+		return err
+	}
+
+	log.Info(ctx, "Service offering deleted successfully", zap.String("service_id", serviceID.String()))
+
+	return nil
+}
+
+// getBusinessServiceOffering loads a service offering and verifies it belongs to the business
+func (s *businessService) getBusinessServiceOffering(ctx context.Context, businessID, serviceID uuid.UUID) (*models.ServiceOffering, error) {
+	offering, err := s.offeringRepo.FindByID(ctx, serviceID)
+	if err != nil {
+		log.Error(ctx, "Failed to find service offering", zap.Error(err), zap.String("service_id", serviceID.String()))
+		return nil, err
+	}
+
+	if offering.BusinessID != businessID {
+		log.Warn(ctx, "Service offering does not belong to business",
+			zap.String("service_id", serviceID.String()),
+			zap.String("business_id", businessID.String()),
+		)
+		return nil, fmt.Errorf("service offering not found")
+	}
+
+	return offering, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// GetCancellationPolicy returns the cancellation policy of a business
+func (s *businessService) GetCancellationPolicy(ctx context.Context, businessID uuid.UUID) (*models.CancellationPolicyResponse, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.GetCancellationPolicy",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	business, err := s.businessRepo.FindByID(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to find business", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return business.CancellationPolicy(), nil
+}
+
+// SetCancellationPolicy configures how long before an appointment customers of a business
+// may cancel or reschedule it for free
+func (s *businessService) SetCancellationPolicy(ctx context.Context, businessID uuid.UUID, req *models.SetCancellationPolicyRequest) (*models.CancellationPolicyResponse, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.SetCancellationPolicy",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Setting cancellation policy",
+		zap.String("business_id", businessID.String()),
+		zap.Int("notice_minutes", *req.NoticeMinutes),
+	)
+
+	business, err := s.businessRepo.FindByID(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to find business", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.businessRepo.UpdateCancellationPolicy(ctx, businessID, *req.NoticeMinutes); err != nil {
+		log.Error(ctx, "Failed to store cancellation policy", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Cancellation policy updated successfully", zap.String("business_id", businessID.String()))
+
+	business.CancellationNoticeMinutes = *req.NoticeMinutes
+	return business.CancellationPolicy(), nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// GetOpeningHours returns the timezone and weekly opening hours of a business
+func (s *businessService) GetOpeningHours(ctx context.Context, businessID uuid.UUID) (*models.OpeningHoursResponse, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.GetOpeningHours",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	business, err := s.businessRepo.FindByID(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to find business", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	hours, err := s.hoursRepo.FindByBusinessID(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to get opening hours", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return &models.OpeningHoursResponse{
+		BusinessID: businessID,
+		Timezone:   business.Timezone,
+		Hours:      hours,
+	}, nil
+}
+
+// SetOpeningHours replaces the timezone and weekly opening hours of a business
+func (s *businessService) SetOpeningHours(ctx context.Context, businessID uuid.UUID, req *models.SetOpeningHoursRequest) (*models.OpeningHoursResponse, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.SetOpeningHours",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Setting opening hours",
+		zap.String("business_id", businessID.String()),
+		zap.String("timezone", req.Timezone),
+		zap.Int("intervals", len(req.Hours)),
+	)
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "Invalid opening hours", zap.Error(err), zap.String("business_id", businessID.String()))
+		return nil, err
+	}
+
+	hours := req.ToOpeningHours(businessID)
+
+	if err := s.hoursRepo.Replace(ctx, businessID, req.Timezone, hours); err != nil {
+		log.Error(ctx, "Failed to store opening hours", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Opening hours updated successfully", zap.String("business_id", businessID.String()))
+
+	return &models.OpeningHoursResponse{
+		BusinessID: businessID,
+		Timezone:   req.Timezone,
+		Hours:      hours,
+	}, nil
+}
+
+// CreateClosure adds a holiday or exceptional closure to a business
+func (s *businessService) CreateClosure(ctx context.Context, businessID uuid.UUID, req *models.CreateBusinessClosureRequest) (*models.BusinessClosure, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.CreateClosure",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Creating business closure",
+		zap.String("business_id", businessID.String()),
+		zap.Time("starts_at", req.StartsAt),
+		zap.Time("ends_at", req.EndsAt),
+	)
+
+	if err := req.Validate(); err != nil {
+		log.Warn(ctx, "Invalid closure period", zap.String("business_id", businessID.String()))
+		return nil, err
+	}
+
+	closure := req.ToBusinessClosure(businessID)
+
+	if err := s.closureRepo.Create(ctx, closure); err != nil {
+		log.Error(ctx, "Failed to create business closure", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Business closure created successfully", zap.String("closure_id", closure.ID.String()))
+
+	return closure, nil
+}
+
+// GetUpcomingClosures lists the current and future closures of a business
+func (s *businessService) GetUpcomingClosures(ctx context.Context, businessID uuid.UUID) ([]*models.BusinessClosure, error) {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.GetUpcomingClosures",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	if _, err := s.businessRepo.FindByID(ctx, businessID); err != nil {
+		log.Error(ctx, "Failed to find business", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	closures, err := s.closureRepo.FindByBusinessID(ctx, businessID, time.Now())
+	if err != nil {
+		log.Error(ctx, "Failed to get business closures", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if closures == nil {
+		closures = []*models.BusinessClosure{}
+	}
+
+	return closures, nil
+}
+
+// DeleteClosure removes a closure from a business
+func (s *businessService) DeleteClosure(ctx context.Context, businessID, closureID uuid.UUID) error {
+	ctx, span := businessTracer.Start(ctx, "BusinessService.DeleteClosure",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("closure_id", closureID.String()),
+		),
+	)
+	defer span.End()
+
+	closure, err := s.closureRepo.FindByID(ctx, closureID)
+	if err != nil {
+		log.Warn(ctx, "Failed to find business closure", zap.Error(err), zap.String("closure_id", closureID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	if closure.BusinessID != businessID {
+		log.Warn(ctx, "Closure does not belong to business",
+			zap.String("closure_id", closureID.String()),
+			zap.String("business_id", businessID.String()),
+		)
+		return fmt.Errorf("business closure not found")
+	}
+
+	if err := s.closureRepo.Delete(ctx, closureID); err != nil {
+		log.Error(ctx, "Failed to delete business closure", zap.Error(err), zap.String("closure_id", closureID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	log.Info(ctx, "Business closure deleted successfully", zap.String("closure_id", closureID.String()))
+
+	return nil
+}
+
+// toResponse converts a business to its response, including whether it is open now.
+// If the schedule cannot be loaded the business is reported as closed.
+func (s *businessService) toResponse(ctx context.Context, business *models.Business) *models.BusinessResponse {
+	response := business.ToResponse()
+
+	schedule, err := loadBusinessSchedule(ctx, s.hoursRepo, s.closureRepo, business)
+	if err != nil {
+		return response
+	}
+
+	response.ApplySchedule(schedule, time.Now())
+	return response
+}
+
+// loadBusinessSchedule loads the opening hours and upcoming closures of a business
+func loadBusinessSchedule(
+	ctx context.Context,
+	hoursRepo repositories.OpeningHoursRepository,
+	closureRepo repositories.BusinessClosureRepository,
+	business *models.Business,
+) (*models.BusinessSchedule, error) {
+	hours, err := hoursRepo.FindByBusinessID(ctx, business.ID)
+	if err != nil {
+		log.Error(ctx, "Failed to get opening hours", zap.Error(err), zap.String("business_id", business.ID.String()))
+		return nil, err
+	}
+
+	closures, err := closureRepo.FindByBusinessID(ctx, business.ID, time.Now())
+	if err != nil {
+		log.Error(ctx, "Failed to get business closures", zap.Error(err), zap.String("business_id", business.ID.String()))
+		return nil, err
+	}
+
+	schedule, err := models.NewBusinessSchedule(business, hours, closures)
+	if err != nil {
+		log.Error(ctx, "Invalid business timezone", zap.Error(err), zap.String("timezone", business.Timezone))
+		return nil, err
+	}
+
+	return schedule, nil
+}
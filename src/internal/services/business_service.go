@@ -22,21 +22,59 @@ type BusinessService interface {
 	GetBusinessByID(ctx context.Context, id uuid.UUID) (*models.BusinessResponse, error)
 	GetBusinessesByOwner(ctx context.Context, ownerID uuid.UUID) ([]*models.BusinessResponse, error)
 	ListAllBusinesses(ctx context.Context) ([]*models.BusinessResponse, error)
-	UpdateBusiness(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, req *models.UpdateBusinessRequest) (*models.BusinessResponse, error)
-	DeleteBusiness(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error
+	UpdateBusiness(ctx context.Context, id uuid.UUID, req *models.UpdateBusinessRequest) (*models.BusinessResponse, error)
+	DeleteBusiness(ctx context.Context, id uuid.UUID) error
+
+	// Service catalog
+	CreateServiceOffering(ctx context.Context, businessID uuid.UUID, req *models.CreateServiceOfferingRequest) (*models.ServiceOfferingResponse, error)
+	GetServiceOfferings(ctx context.Context, businessID uuid.UUID) ([]*models.ServiceOfferingResponse, error)
+	GetServiceOfferingByID(ctx context.Context, businessID, serviceID uuid.UUID) (*models.ServiceOfferingResponse, error)
+	UpdateServiceOffering(ctx context.Context, businessID, serviceID uuid.UUID, req *models.UpdateServiceOfferingRequest) (*models.ServiceOfferingResponse, error)
+	DeleteServiceOffering(ctx context.Context, businessID, serviceID uuid.UUID) error
+
+	// Opening hours
+	GetOpeningHours(ctx context.Context, businessID uuid.UUID) (*models.OpeningHoursResponse, error)
+	SetOpeningHours(ctx context.Context, businessID uuid.UUID, req *models.SetOpeningHoursRequest) (*models.OpeningHoursResponse, error)
+	CreateClosure(ctx context.Context, businessID uuid.UUID, req *models.CreateBusinessClosureRequest) (*models.BusinessClosure, error)
+	GetUpcomingClosures(ctx context.Context, businessID uuid.UUID) ([]*models.BusinessClosure, error)
+	DeleteClosure(ctx context.Context, businessID, closureID uuid.UUID) error
+
+	// Cancellation policy
+	GetCancellationPolicy(ctx context.Context, businessID uuid.UUID) (*models.CancellationPolicyResponse, error)
+	SetCancellationPolicy(ctx context.Context, businessID uuid.UUID, req *models.SetCancellationPolicyRequest) (*models.CancellationPolicyResponse, error)
+
+	// Members
+	GetMembers(ctx context.Context, businessID uuid.UUID) ([]*models.BusinessMember, error)
+	SetMember(ctx context.Context, businessID, userID uuid.UUID, req *models.SetBusinessMemberRequest) (*models.BusinessMember, error)
+	RemoveMember(ctx context.Context, businessID, userID uuid.UUID) error
 }
 
 // businessService implements BusinessService
 type businessService struct {
 	businessRepo repositories.BusinessRepository
 	userRepo     repositories.UserRepository
+	offeringRepo repositories.ServiceOfferingRepository
+	hoursRepo    repositories.OpeningHoursRepository
+	closureRepo  repositories.BusinessClosureRepository
+	memberRepo   repositories.BusinessMemberRepository
 }
 
 // NewBusinessService creates a new instance of BusinessService
-func NewBusinessService(businessRepo repositories.BusinessRepository, userRepo repositories.UserRepository) BusinessService {
+func NewBusinessService(
+	businessRepo repositories.BusinessRepository,
+	userRepo repositories.UserRepository,
+	offeringRepo repositories.ServiceOfferingRepository,
+	hoursRepo repositories.OpeningHoursRepository,
+	closureRepo repositories.BusinessClosureRepository,
+	memberRepo repositories.BusinessMemberRepository,
+) BusinessService {
 	return &businessService{
 		businessRepo: businessRepo,
 		userRepo:     userRepo,
+		offeringRepo: offeringRepo,
+		hoursRepo:    hoursRepo,
+		closureRepo:  closureRepo,
+		memberRepo:   memberRepo,
 	}
 }
 
@@ -94,7 +132,7 @@ func (s *businessService) CreateBusiness(ctx context.Context, ownerID uuid.UUID,
 
 	span.SetAttributes(attribute.String("business_id", business.ID.String()))
 
-	return business.ToResponse(), nil
+	return s.toResponse(ctx, business), nil
 }
 
 // GetBusinessByID retrieves a business by ID
@@ -118,7 +156,7 @@ func (s *businessService) GetBusinessByID(ctx context.Context, id uuid.UUID) (*m
 		return nil, err
 	}
 
-	return business.ToResponse(), nil
+	return s.toResponse(ctx, business), nil
 }
 
 // GetBusinessesByOwner retrieves all businesses owned by a specific user
@@ -145,7 +183,7 @@ func (s *businessService) GetBusinessesByOwner(ctx context.Context, ownerID uuid
 	// Convert to response format
 	responses := make([]*models.BusinessResponse, len(businesses))
 	for i, business := range businesses {
-		responses[i] = business.ToResponse()
+		responses[i] = s.toResponse(ctx, business)
 	}
 
 	log.Info(ctx, "Successfully retrieved businesses by owner",
@@ -174,7 +212,7 @@ func (s *businessService) ListAllBusinesses(ctx context.Context) ([]*models.Busi
 	// Convert to response format
 	responses := make([]*models.BusinessResponse, len(businesses))
 	for i, business := range businesses {
-		responses[i] = business.ToResponse()
+		responses[i] = s.toResponse(ctx, business)
 	}
 
 	log.Info(ctx, "Successfully listed all businesses", zap.Int("count", len(responses)))
@@ -184,19 +222,15 @@ func (s *businessService) ListAllBusinesses(ctx context.Context) ([]*models.Busi
 }
 
 // UpdateBusiness updates an existing business
-func (s *businessService) UpdateBusiness(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, req *models.UpdateBusinessRequest) (*models.BusinessResponse, error) {
+func (s *businessService) UpdateBusiness(ctx context.Context, id uuid.UUID, req *models.UpdateBusinessRequest) (*models.BusinessResponse, error) {
 	ctx, span := businessTracer.Start(ctx, "BusinessService.UpdateBusiness",
 		trace.WithAttributes(
 			attribute.String("business_id", id.String()),
-			attribute.String("owner_id", ownerID.String()),
 		),
 	)
 	defer span.End()
 
-	log.Info(ctx, "Updating business",
-		zap.String("business_id", id.String()),
-		zap.String("owner_id", ownerID.String()),
-	)
+	log.Info(ctx, "Updating business", zap.String("business_id", id.String()))
 
 	// Get existing business
 	business, err := s.businessRepo.FindByID(ctx, id)
@@ -209,16 +243,6 @@ func (s *businessService) UpdateBusiness(ctx context.Context, id uuid.UUID, owne
 		return nil, err
 	}
 
-	// Verify ownership
-	if business.OwnerID != ownerID {
-		log.Warn(ctx, "User is not the owner of this business",
-			zap.String("business_id", id.String()),
-			zap.String("owner_id", ownerID.String()),
-			zap.String("actual_owner_id", business.OwnerID.String()),
-		)
-		return nil, fmt.Errorf("you are not authorized to update this business")
-	}
-
 	business.ApplyUpdateRequest(req)
 
 	// Save to database
@@ -235,27 +259,22 @@ func (s *businessService) UpdateBusiness(ctx context.Context, id uuid.UUID, owne
 		zap.String("business_id", business.ID.String()),
 	)
 
-	return business.ToResponse(), nil
+	return s.toResponse(ctx, business), nil
 }
 
 // DeleteBusiness deletes a business
-func (s *businessService) DeleteBusiness(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+func (s *businessService) DeleteBusiness(ctx context.Context, id uuid.UUID) error {
 	ctx, span := businessTracer.Start(ctx, "BusinessService.DeleteBusiness",
 		trace.WithAttributes(
 			attribute.String("business_id", id.String()),
-			attribute.String("owner_id", ownerID.String()),
 		),
 	)
 	defer span.End()
 
-	log.Info(ctx, "Deleting business",
-		zap.String("business_id", id.String()),
-		zap.String("owner_id", ownerID.String()),
-	)
+	log.Info(ctx, "Deleting business", zap.String("business_id", id.String()))
 
 	// Get existing business
-	business, err := s.businessRepo.FindByID(ctx, id)
-	if err != nil {
+	if _, err := s.businessRepo.FindByID(ctx, id); err != nil {
 		log.Error(ctx, "Failed to find business",
 			zap.Error(err),
 			zap.String("business_id", id.String()),
@@ -264,16 +283,6 @@ func (s *businessService) DeleteBusiness(ctx context.Context, id uuid.UUID, owne
 		return err
 	}
 
-	// Verify ownership
-	if business.OwnerID != ownerID {
-		log.Warn(ctx, "User is not the owner of this business",
-			zap.String("business_id", id.String()),
-			zap.String("owner_id", ownerID.String()),
-			zap.String("actual_owner_id", business.OwnerID.String()),
-		)
-		return fmt.Errorf("you are not authorized to delete this business")
-	}
-
 	// Delete from database
 	if err := s.businessRepo.Delete(ctx, id); err != nil {
 		log.Error(ctx, "Failed to delete business from database",
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/config"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var loginThrottleTracer = otel.Tracer("login-throttle-service")
+
+// LoginThrottledError is returned when a login is refused because of too many failed attempts
+type LoginThrottledError struct {
+	Locked     bool // The account reached the lockout threshold, as opposed to a backoff delay or a client IP lockout
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return "account temporarily locked"
+	}
+	return "too many login attempts"
+}
+
+// LoginThrottleService defines the interface for login brute-force protection
+type LoginThrottleService interface {
+	ReserveAttempt(ctx context.Context, email, clientIP string) error
+	RecordFailure(ctx context.Context, email, clientIP string)
+	RecordSuccess(ctx context.Context, email, clientIP string)
+}
+
+// loginThrottleService implements LoginThrottleService
+type loginThrottleService struct {
+	throttleRepo repositories.LoginThrottleRepository
+	config       *config.LoginThrottleConfig
+}
+
+// NewLoginThrottleService creates a new instance of LoginThrottleService
+func NewLoginThrottleService(throttleRepo repositories.LoginThrottleRepository, config *config.LoginThrottleConfig) LoginThrottleService {
+	return &loginThrottleService{
+		throttleRepo: throttleRepo,
+		config:       config,
+	}
+}
+
+// ReserveAttempt counts a login attempt against the client IP and the email before the password is verified,
+// and returns a *LoginThrottledError if either is blocked or the attempt goes over its limit. Counting first means
+// parallel attempts cannot all pass the limit before any of their failures is recorded. The attempt stays counted
+// as a failure unless RecordSuccess gives it back.
+func (s *loginThrottleService) ReserveAttempt(ctx context.Context, email, clientIP string) error {
+	ctx, span := loginThrottleTracer.Start(ctx, "LoginThrottleService.ReserveAttempt",
+		trace.WithAttributes(
+			attribute.String("email", email),
+			attribute.String("client_ip", clientIP),
+		),
+	)
+	defer span.End()
+
+	now := time.Now()
+
+	// The client IP goes first, so an IP that is already blocked does not count attempts against the email
+	if clientIP != "" {
+		throttled, err := s.reserve(ctx, models.LoginThrottleScopeIP, clientIP, s.config.MaxAttemptsPerIP, now)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		if throttled != nil {
+			s.logBlocked(ctx, email, clientIP, throttled)
+			return throttled
+		}
+	}
+
+	throttled, err := s.reserve(ctx, models.LoginThrottleScopeEmail, normalizeLoginEmail(email), s.config.MaxAttemptsPerEmail, now)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if throttled != nil {
+		s.logBlocked(ctx, email, clientIP, throttled)
+		return throttled
+	}
+
+	return nil
+}
+
+// RecordFailure blocks further attempts for the email and the client IP, whose failed attempt was counted by
+// ReserveAttempt, for an exponentially growing delay, or for the lockout duration once the threshold is reached.
+// Errors are logged rather than returned, so the caller still reports the failed login itself.
+func (s *loginThrottleService) RecordFailure(ctx context.Context, email, clientIP string) {
+	ctx, span := loginThrottleTracer.Start(ctx, "LoginThrottleService.RecordFailure",
+		trace.WithAttributes(
+			attribute.String("email", email),
+			attribute.String("client_ip", clientIP),
+		),
+	)
+	defer span.End()
+
+	now := time.Now()
+
+	if err := s.lockAfterFailure(ctx, models.LoginThrottleScopeEmail, normalizeLoginEmail(email), s.config.MaxAttemptsPerEmail, now); err != nil {
+		log.Error(ctx, "Failed to record failed login for email", zap.Error(err), zap.String("email", email))
+		span.RecordError(err)
+	}
+
+	if clientIP == "" {
+		return
+	}
+
+	if err := s.lockAfterFailure(ctx, models.LoginThrottleScopeIP, clientIP, s.config.MaxAttemptsPerIP, now); err != nil {
+		log.Error(ctx, "Failed to record failed login for client IP", zap.Error(err), zap.String("client_ip", clientIP))
+		span.RecordError(err)
+	}
+}
+
+// RecordSuccess forgets the failed logins of the email and gives back the attempt reserved for the client IP.
+// Earlier client IP failures are kept until they expire, so an attacker cannot clear them by logging into an
+// account of their own.
+func (s *loginThrottleService) RecordSuccess(ctx context.Context, email, clientIP string) {
+	if err := s.throttleRepo.Reset(ctx, models.LoginThrottleScopeEmail, normalizeLoginEmail(email)); err != nil {
+		log.Error(ctx, "Failed to reset login throttle", zap.Error(err), zap.String("email", email))
+	}
+
+	if clientIP == "" {
+		return
+	}
+
+	if err := s.throttleRepo.Release(ctx, models.LoginThrottleScopeIP, clientIP); err != nil {
+		log.Error(ctx, "Failed to release login attempt for client IP", zap.Error(err), zap.String("client_ip", clientIP))
+	}
+}
+
+// find loads the throttle of a key, returning nil if the key has no failed logins
+func (s *loginThrottleService) find(ctx context.Context, scope models.LoginThrottleScope, key string) (*models.LoginThrottle, error) {
+	throttle, err := s.throttleRepo.Find(ctx, scope, key)
+	if err != nil {
+		if err.Error() == "login throttle not found" {
+			return nil, nil
+		}
+		log.Error(ctx, "Failed to check login throttle", zap.Error(err), zap.String("scope", string(scope)))
+		return nil, err
+	}
+	return throttle, nil
+}
+
+// reserve counts an attempt for a key and returns the throttle error if the key is locked or the attempt
+// goes over maxAttempts, in which case the key is locked for the lockout duration
+func (s *loginThrottleService) reserve(ctx context.Context, scope models.LoginThrottleScope, key string, maxAttempts int, now time.Time) (*LoginThrottledError, error) {
+	throttle, err := s.throttleRepo.ReserveAttempt(ctx, scope, key, now, s.config.FailureWindow)
+	if err != nil {
+		log.Error(ctx, "Failed to reserve login attempt", zap.Error(err), zap.String("scope", string(scope)))
+		return nil, err
+	}
+
+	if retryAfter := throttle.RetryAfter(now); retryAfter > 0 {
+		return &LoginThrottledError{
+			Locked:     scope == models.LoginThrottleScopeEmail && throttle.FailedAttempts >= maxAttempts,
+			RetryAfter: retryAfter,
+		}, nil
+	}
+
+	if throttle.FailedAttempts <= maxAttempts {
+		return nil, nil
+	}
+
+	if err := s.throttleRepo.Lock(ctx, scope, key, now.Add(s.config.LockoutDuration)); err != nil {
+		log.Error(ctx, "Failed to lock login", zap.Error(err), zap.String("scope", string(scope)))
+	}
+	return &LoginThrottledError{
+		Locked:     scope == models.LoginThrottleScopeEmail,
+		RetryAfter: s.config.LockoutDuration,
+	}, nil
+}
+
+// lockAfterFailure locks a key whose failed attempt was already counted for the resulting backoff delay
+func (s *loginThrottleService) lockAfterFailure(ctx context.Context, scope models.LoginThrottleScope, key string, maxAttempts int, now time.Time) error {
+	throttle, err := s.find(ctx, scope, key)
+	if err != nil || throttle == nil {
+		return err
+	}
+
+	delay := loginBackoffDelay(throttle.FailedAttempts, maxAttempts, s.config.BaseDelay, s.config.LockoutDuration)
+	if delay <= 0 {
+		return nil
+	}
+
+	return s.throttleRepo.Lock(ctx, scope, key, now.Add(delay))
+}
+
+// logBlocked logs a login refused by the throttle
+func (s *loginThrottleService) logBlocked(ctx context.Context, email, clientIP string, throttled *LoginThrottledError) {
+	log.Warn(ctx, "Login blocked by throttle",
+		zap.String("email", email),
+		zap.String("client_ip", clientIP),
+		zap.Bool("locked", throttled.Locked),
+		zap.Duration("retry_after", throttled.RetryAfter),
+	)
+}
+
+// loginBackoffDelay returns how long to block logins after the given number of consecutive failures:
+// the base delay doubled for each failure after the first, capped at the lockout duration, which
+// applies in full once maxAttempts is reached
+func loginBackoffDelay(failures, maxAttempts int, base, lockout time.Duration) time.Duration {
+	if failures >= maxAttempts {
+		return lockout
+	}
+
+	delay := base
+	for i := 1; i < failures && delay < lockout; i++ {
+		delay *= 2
+	}
+
+	if delay > lockout {
+		return lockout
+	}
+	return delay
+}
+
+// normalizeLoginEmail returns the throttle key of an email, so case variants share one counter
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/config"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLoginThrottleRepository keeps the throttles in memory, each call being atomic like its SQL statement
+type fakeLoginThrottleRepository struct {
+	repositories.LoginThrottleRepository
+	mu        sync.Mutex
+	throttles map[string]*models.LoginThrottle
+}
+
+func (r *fakeLoginThrottleRepository) throttle(scope models.LoginThrottleScope, key string) *models.LoginThrottle {
+	return r.throttles[string(scope)+":"+key]
+}
+
+func (r *fakeLoginThrottleRepository) Find(ctx context.Context, scope models.LoginThrottleScope, key string) (*models.LoginThrottle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	throttle := r.throttle(scope, key)
+	if throttle == nil {
+		return nil, fmt.Errorf("login throttle not found")
+	}
+	copied := *throttle
+	return &copied, nil
+}
+
+func (r *fakeLoginThrottleRepository) ReserveAttempt(ctx context.Context, scope models.LoginThrottleScope, key string, at time.Time, window time.Duration) (*models.LoginThrottle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	throttle := r.throttle(scope, key)
+	switch {
+	case throttle == nil:
+		throttle = &models.LoginThrottle{Scope: scope, Key: key, FailedAttempts: 1, LastFailedAt: at}
+		r.throttles[string(scope)+":"+key] = throttle
+	case throttle.RetryAfter(at) > 0:
+	case throttle.LastFailedAt.Before(at.Add(-window)):
+		throttle.FailedAttempts = 1
+		throttle.LastFailedAt = at
+	default:
+		throttle.FailedAttempts++
+		throttle.LastFailedAt = at
+	}
+	copied := *throttle
+	return &copied, nil
+}
+
+func (r *fakeLoginThrottleRepository) Lock(ctx context.Context, scope models.LoginThrottleScope, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if throttle := r.throttle(scope, key); throttle != nil && (throttle.LockedUntil == nil || until.After(*throttle.LockedUntil)) {
+		throttle.LockedUntil = &until
+	}
+	return nil
+}
+
+func (r *fakeLoginThrottleRepository) Release(ctx context.Context, scope models.LoginThrottleScope, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if throttle := r.throttle(scope, key); throttle != nil && throttle.FailedAttempts > 0 {
+		throttle.FailedAttempts--
+	}
+	return nil
+}
+
+func (r *fakeLoginThrottleRepository) Reset(ctx context.Context, scope models.LoginThrottleScope, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.throttles, string(scope)+":"+key)
+	return nil
+}
+
+func newTestLoginThrottleService() (LoginThrottleService, *fakeLoginThrottleRepository) {
+	throttleRepo := &fakeLoginThrottleRepository{throttles: map[string]*models.LoginThrottle{}}
+	return NewLoginThrottleService(throttleRepo, &config.LoginThrottleConfig{
+		MaxAttemptsPerEmail: 5,
+		MaxAttemptsPerIP:    20,
+		BaseDelay:           time.Second,
+		LockoutDuration:     15 * time.Minute,
+		FailureWindow:       15 * time.Minute,
+	}), throttleRepo
+}
+
+func TestReserveAttemptAdmitsParallelAttemptsOnlyUpToTheLimit(t *testing.T) {
+	service, _ := newTestLoginThrottleService()
+	ctx := log.Initialize(context.Background())
+
+	var wg sync.WaitGroup
+	results := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results <- service.ReserveAttempt(ctx, "user@example.com", fmt.Sprintf("203.0.113.%d", i))
+		}(i)
+	}
+	wg.Wait()
+	close(results)
+
+	admitted := 0
+	for err := range results {
+		var throttled *LoginThrottledError
+		switch {
+		case err == nil:
+			admitted++
+		case !errors.As(err, &throttled):
+			t.Fatalf("expected a throttle error, got %v", err)
+		}
+	}
+
+	if admitted != 5 {
+		t.Fatalf("expected 5 attempts to be admitted, got %d", admitted)
+	}
+	if err := service.ReserveAttempt(ctx, "user@example.com", "198.51.100.1"); err == nil {
+		t.Fatal("expected the account to be locked after the parallel attempts")
+	}
+}
+
+func TestRecordSuccessGivesBackTheClientIPAttempt(t *testing.T) {
+	service, throttleRepo := newTestLoginThrottleService()
+	ctx := log.Initialize(context.Background())
+
+	for i := 0; i < 30; i++ {
+		email := fmt.Sprintf("user%d@example.com", i)
+		if err := service.ReserveAttempt(ctx, email, "198.51.100.1"); err != nil {
+			t.Fatalf("expected successful login %d to be admitted, got %v", i, err)
+		}
+		service.RecordSuccess(ctx, email, "198.51.100.1")
+	}
+
+	if throttle := throttleRepo.throttle(models.LoginThrottleScopeIP, "198.51.100.1"); throttle.FailedAttempts != 0 {
+		t.Fatalf("expected no failed attempts left for the client IP, got %d", throttle.FailedAttempts)
+	}
+}
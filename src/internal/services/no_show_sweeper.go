@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/repositories"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var noShowTracer = otel.Tracer("no-show-sweeper")
+
+// NoShowSweeper periodically marks called tickets as no_show when the customer
+// neither checked in nor arrived within the tolerance of the service, and skipped
+// tickets the business did not recall within that tolerance
+type NoShowSweeper struct {
+	ticketRepo       repositories.TicketRepository
+	reputation       ReputationService
+	interval         time.Duration
+	defaultTolerance time.Duration
+	ticker           *time.Ticker
+	stopChan         chan struct{}
+	wg               sync.WaitGroup
+}
+
+// NewNoShowSweeper creates a new no-show sweeper
+func NewNoShowSweeper(ticketRepo repositories.TicketRepository, reputation ReputationService, interval, defaultTolerance time.Duration) *NoShowSweeper {
+	return &NoShowSweeper{
+		ticketRepo:       ticketRepo,
+		reputation:       reputation,
+		interval:         interval,
+		defaultTolerance: defaultTolerance,
+		stopChan:         make(chan struct{}),
+	}
+}
+
+// Start begins sweeping in the background until Stop is called or ctx is done
+func (s *NoShowSweeper) Start(ctx context.Context) {
+	log.Info(ctx, "Starting no-show sweeper",
+		zap.Duration("interval", s.interval),
+		zap.Duration("default_tolerance", s.defaultTolerance),
+	)
+
+	s.ticker = time.NewTicker(s.interval)
+
+	s.wg.Add(1)
+	go s.sweepLoop(ctx)
+}
+
+// Stop stops the sweeper and waits for an in-flight sweep to finish
+func (s *NoShowSweeper) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	close(s.stopChan)
+	s.wg.Wait()
+}
+
+// sweepLoop runs a sweep on every tick
+func (s *NoShowSweeper) sweepLoop(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.ticker.C:
+			s.sweep(ctx)
+		case <-s.stopChan:
+			log.Info(ctx, "No-show sweeper stopped")
+			return
+		case <-ctx.Done():
+			log.Info(ctx, "No-show sweeper context cancelled")
+			return
+		}
+	}
+}
+
+// sweep marks every overdue called ticket as no_show
+func (s *NoShowSweeper) sweep(ctx context.Context) {
+	ctx, span := noShowTracer.Start(ctx, "NoShowSweeper.Sweep")
+	defer span.End()
+
+	tickets, err := s.ticketRepo.MarkOverdueNoShows(ctx, time.Now(), s.defaultTolerance)
+	if err != nil {
+		log.Error(ctx, "Failed to sweep no-show tickets", zap.Error(err))
+		span.RecordError(err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("no_show_count", len(tickets)))
+
+	for _, ticket := range tickets {
+		log.Info(ctx, "Ticket marked as no-show",
+			zap.String("ticket_id", ticket.ID.String()),
+			zap.String("queue_id", ticket.QueueID.String()),
+			zap.String("customer_id", ticket.CustomerID.String()),
+			zap.Int("number", ticket.Number),
+		)
+
+		// A failed recalculation is logged and corrected by the customer's next finished ticket
+		_, _ = s.reputation.RecalculateReputation(ctx, ticket.CustomerID)
+	}
+}
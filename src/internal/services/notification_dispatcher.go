@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/config"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+var notificationDispatcherTracer = otel.Tracer("notification-dispatcher")
+
+// notificationLease is how long a claimed notification is hidden from other dispatchers.
+// It must outlast a send, which the WhatsApp HTTP client bounds to 30 seconds.
+const notificationLease = 2 * time.Minute
+
+// NotificationDispatcher periodically sends the due notifications of the outbox through WhatsApp.
+// A failed send is retried with exponential backoff; after the last attempt, or as soon as the
+// Graph API rejects the message for good, the notification is dead.
+type NotificationDispatcher struct {
+	notificationRepo repositories.NotificationRepository
+	whatsapp         WhatsAppService
+	config           *config.NotificationConfig
+	ticker           *time.Ticker
+	stopChan         chan struct{}
+	wg               sync.WaitGroup
+}
+
+// NewNotificationDispatcher creates a new notification dispatcher
+func NewNotificationDispatcher(notificationRepo repositories.NotificationRepository, whatsapp WhatsAppService, config *config.NotificationConfig) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		notificationRepo: notificationRepo,
+		whatsapp:         whatsapp,
+		config:           config,
+		stopChan:         make(chan struct{}),
+	}
+}
+
+// Start begins dispatching in the background until Stop is called or ctx is done
+func (d *NotificationDispatcher) Start(ctx context.Context) {
+	log.Info(ctx, "Starting notification dispatcher",
+		zap.Duration("poll_interval", d.config.PollInterval),
+		zap.Int("batch_size", d.config.BatchSize),
+		zap.Int("max_attempts", d.config.MaxAttempts),
+	)
+
+	d.ticker = time.NewTicker(d.config.PollInterval)
+
+	d.wg.Add(1)
+	go d.dispatchLoop(ctx)
+}
+
+// Stop stops the dispatcher and waits for an in-flight batch to finish
+func (d *NotificationDispatcher) Stop() {
+	if d.ticker != nil {
+		d.ticker.Stop()
+	}
+	close(d.stopChan)
+	d.wg.Wait()
+}
+
+// dispatchLoop sends a batch on every tick
+func (d *NotificationDispatcher) dispatchLoop(ctx context.Context) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.ticker.C:
+			d.dispatch(ctx)
+		case <-d.stopChan:
+			log.Info(ctx, "Notification dispatcher stopped")
+			return
+		case <-ctx.Done():
+			log.Info(ctx, "Notification dispatcher context cancelled")
+			return
+		}
+	}
+}
+
+// dispatch sends the notifications that are due
+func (d *NotificationDispatcher) dispatch(ctx context.Context) {
+	ctx, span := notificationDispatcherTracer.Start(ctx, "NotificationDispatcher.Dispatch")
+	defer span.End()
+
+	notifications, err := d.notificationRepo.ClaimDue(ctx, time.Now(), d.config.BatchSize, notificationLease)
+	if err != nil {
+		log.Error(ctx, "Failed to claim notifications", zap.Error(err))
+		span.RecordError(err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("notification_count", len(notifications)))
+
+	for _, notification := range notifications {
+		d.send(ctx, notification)
+	}
+}
+
+// send makes one attempt at a claimed notification and records its outcome
+func (d *NotificationDispatcher) send(ctx context.Context, notification *models.Notification) {
+	message := notification.Message
+
+	response, err := d.whatsapp.SendMessage(ctx, &message)
+	if err == nil {
+		if notification.BusinessID != nil || notification.TicketID != nil {
+			// The message is sent either way; only business members lose sight of it
+			if err := d.whatsapp.LinkMessage(ctx, response.MessageID, notification.BusinessID, notification.TicketID); err != nil {
+				log.Warn(ctx, "Failed to link sent notification", zap.Error(err), zap.String("notification_id", notification.ID.String()))
+			}
+		}
+		if err := d.notificationRepo.MarkSent(ctx, notification.ID, response.MessageID, time.Now()); err != nil {
+			// The lease expires and the message is sent again; a duplicate beats a lost message
+			log.Error(ctx, "Failed to mark notification as sent", zap.Error(err), zap.String("notification_id", notification.ID.String()))
+		}
+		return
+	}
+
+	// A message the Graph API rejected is rejected again; retrying it only delays the rest of the outbox
+	var apiErr *WhatsAppAPIError
+	permanent := errors.As(err, &apiErr) && apiErr.Permanent()
+
+	if permanent || notification.Attempts >= d.config.MaxAttempts {
+		log.Error(ctx, "Notification dead",
+			zap.Error(err),
+			zap.Bool("permanent", permanent),
+			zap.String("notification_id", notification.ID.String()),
+			zap.String("to", message.To),
+			zap.Int("attempts", notification.Attempts),
+		)
+		if err := d.notificationRepo.MarkDead(ctx, notification.ID, err.Error()); err != nil {
+			log.Error(ctx, "Failed to mark notification as dead", zap.Error(err), zap.String("notification_id", notification.ID.String()))
+		}
+		return
+	}
+
+	next := time.Now().Add(notificationRetryDelay(notification.Attempts, d.config.RetryBaseDelay, d.config.RetryMaxDelay))
+	log.Warn(ctx, "Notification send failed, will retry",
+		zap.Error(err),
+		zap.String("notification_id", notification.ID.String()),
+		zap.Int("attempts", notification.Attempts),
+		zap.Time("next_attempt_at", next),
+	)
+	if err := d.notificationRepo.Reschedule(ctx, notification.ID, next, err.Error()); err != nil {
+		log.Error(ctx, "Failed to reschedule notification", zap.Error(err), zap.String("notification_id", notification.ID.String()))
+	}
+}
+
+// notificationRetryDelay returns how long to wait after the given number of failed attempts:
+// the base delay doubled for each attempt after the first, capped at the maximum delay
+func notificationRetryDelay(attempts int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/config"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeNotificationRepository records the outcome of each attempt
+type fakeNotificationRepository struct {
+	repositories.NotificationRepository
+	rescheduled []uuid.UUID
+	dead        []uuid.UUID
+}
+
+func (r *fakeNotificationRepository) Reschedule(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	r.rescheduled = append(r.rescheduled, id)
+	return nil
+}
+
+func (r *fakeNotificationRepository) MarkDead(ctx context.Context, id uuid.UUID, lastError string) error {
+	r.dead = append(r.dead, id)
+	return nil
+}
+
+// failingWhatsAppService fails every send with the given error
+type failingWhatsAppService struct {
+	WhatsAppService
+	err error
+}
+
+func (s *failingWhatsAppService) SendMessage(ctx context.Context, req *models.SendWhatsAppMessageRequest) (*models.WhatsAppMessageResponse, error) {
+	return nil, s.err
+}
+
+// sendFirstAttempt makes the first attempt at a notification with a send that fails with err
+func sendFirstAttempt(t *testing.T, err error) *fakeNotificationRepository {
+	t.Helper()
+
+	notificationRepo := &fakeNotificationRepository{}
+	dispatcher := NewNotificationDispatcher(notificationRepo, &failingWhatsAppService{err: err}, &config.NotificationConfig{
+		MaxAttempts:    8,
+		RetryBaseDelay: 10 * time.Second,
+		RetryMaxDelay:  30 * time.Minute,
+	})
+
+	notification := models.NewNotification(models.SendWhatsAppMessageRequest{To: "16505551234", Type: models.WhatsAppMessageTypeText, Message: "Your turn is coming"})
+	notification.Attempts = 1
+
+	dispatcher.send(log.Initialize(context.Background()), notification)
+	return notificationRepo
+}
+
+func TestDispatcherGivesUpOnPermanentRejections(t *testing.T) {
+	notificationRepo := sendFirstAttempt(t, &WhatsAppAPIError{StatusCode: http.StatusBadRequest, Body: `{"error":{"code":131026}}`})
+
+	if len(notificationRepo.dead) != 1 || len(notificationRepo.rescheduled) != 0 {
+		t.Fatalf("expected the notification to be dead after the first attempt, got dead=%d rescheduled=%d",
+			len(notificationRepo.dead), len(notificationRepo.rescheduled))
+	}
+}
+
+func TestDispatcherRetriesTransientFailures(t *testing.T) {
+	for name, err := range map[string]error{
+		"server error":  &WhatsAppAPIError{StatusCode: http.StatusInternalServerError},
+		"rate limited":  &WhatsAppAPIError{StatusCode: http.StatusTooManyRequests},
+		"expired token": &WhatsAppAPIError{StatusCode: http.StatusUnauthorized},
+		"network error": fmt.Errorf("failed to send message: connection reset"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			notificationRepo := sendFirstAttempt(t, err)
+
+			if len(notificationRepo.rescheduled) != 1 || len(notificationRepo.dead) != 0 {
+				t.Fatalf("expected the notification to be retried, got dead=%d rescheduled=%d",
+					len(notificationRepo.dead), len(notificationRepo.rescheduled))
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var notificationTracer = otel.Tracer("notification-service")
+
+// NotificationService queues WhatsApp messages in the outbox. They are sent in the background by the
+// NotificationDispatcher, so a slow or failing Graph API neither delays the caller nor loses the message.
+type NotificationService interface {
+	EnqueueText(ctx context.Context, to, body string) error
+	EnqueueTicketText(ctx context.Context, businessID, ticketID uuid.UUID, to, body string) error
+	EnqueueTemplate(ctx context.Context, to string, template *models.WhatsAppTemplateRequest) error
+}
+
+// notificationService implements NotificationService
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+}
+
+// NewNotificationService creates a new instance of NotificationService
+func NewNotificationService(notificationRepo repositories.NotificationRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// EnqueueText queues a text message
+func (s *notificationService) EnqueueText(ctx context.Context, to, body string) error {
+	return s.enqueue(ctx, models.NewNotification(textMessage(to, body)))
+}
+
+// EnqueueTicketText queues a text message about a ticket. The sent message is linked to the ticket
+// and its business, so the members of the business can see whether it was delivered.
+func (s *notificationService) EnqueueTicketText(ctx context.Context, businessID, ticketID uuid.UUID, to, body string) error {
+	notification := models.NewNotification(textMessage(to, body))
+	notification.BusinessID = &businessID
+	notification.TicketID = &ticketID
+	return s.enqueue(ctx, notification)
+}
+
+// EnqueueTemplate queues a template message
+func (s *notificationService) EnqueueTemplate(ctx context.Context, to string, template *models.WhatsAppTemplateRequest) error {
+	return s.enqueue(ctx, models.NewNotification(models.SendWhatsAppMessageRequest{
+		To:       to,
+		Type:     models.WhatsAppMessageTypeTemplate,
+		Template: template,
+	}))
+}
+
+// textMessage builds the send request of a text message
+func textMessage(to, body string) models.SendWhatsAppMessageRequest {
+	return models.SendWhatsAppMessageRequest{
+		To:      to,
+		Type:    models.WhatsAppMessageTypeText,
+		Message: body,
+	}
+}
+
+// enqueue stores the notification in the outbox, due immediately
+func (s *notificationService) enqueue(ctx context.Context, notification *models.Notification) error {
+	message := notification.Message
+
+	ctx, span := notificationTracer.Start(ctx, "NotificationService.Enqueue",
+		trace.WithAttributes(
+			attribute.String("whatsapp.to", message.To),
+			attribute.String("whatsapp.type", string(message.Type)),
+		),
+	)
+	defer span.End()
+
+	if err := s.notificationRepo.Enqueue(ctx, notification); err != nil {
+		log.Error(ctx, "Failed to enqueue notification", zap.Error(err), zap.String("to", message.To))
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.String("notification.id", notification.ID.String()))
+
+	log.Info(ctx, "Notification enqueued",
+		zap.String("notification_id", notification.ID.String()),
+		zap.String("to", message.To),
+		zap.String("type", string(message.Type)),
+	)
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/config"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var passwordResetTracer = otel.Tracer("password-reset-service")
+
+// PasswordResetService defines the interface for password reset operations
+type PasswordResetService interface {
+	RequestReset(ctx context.Context, req *models.RequestPasswordResetRequest) error
+	ConfirmReset(ctx context.Context, req *models.ConfirmPasswordResetRequest, clientIP string) error
+}
+
+// passwordResetService implements PasswordResetService
+type passwordResetService struct {
+	userRepo         repositories.UserRepository
+	codeRepo         repositories.VerificationCodeRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	notifications    NotificationService
+	loginThrottle    LoginThrottleService
+	config           *config.PasswordResetConfig
+}
+
+// NewPasswordResetService creates a new instance of PasswordResetService.
+// notifications may be nil when the WhatsApp integration is not configured, which disables password reset.
+func NewPasswordResetService(
+	userRepo repositories.UserRepository,
+	codeRepo repositories.VerificationCodeRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	notifications NotificationService,
+	loginThrottle LoginThrottleService,
+	config *config.PasswordResetConfig,
+) PasswordResetService {
+	return &passwordResetService{
+		userRepo:         userRepo,
+		codeRepo:         codeRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		notifications:    notifications,
+		loginThrottle:    loginThrottle,
+		config:           config,
+	}
+}
+
+// RequestReset sends a single-use reset code to the verified phone of the user with the given email.
+// Unknown or inactive accounts and accounts without a verified phone are ignored without error, so
+// the endpoint does not reveal which emails are registered.
+func (s *passwordResetService) RequestReset(ctx context.Context, req *models.RequestPasswordResetRequest) error {
+	ctx, span := passwordResetTracer.Start(ctx, "PasswordResetService.RequestReset",
+		trace.WithAttributes(
+			attribute.String("email", req.Email),
+		),
+	)
+	defer span.End()
+
+	if s.notifications == nil {
+		log.Warn(ctx, "Password reset requested but WhatsApp is not configured")
+		return fmt.Errorf("password reset is not available")
+	}
+
+	log.Info(ctx, "Password reset requested", zap.String("email", req.Email))
+
+	user, err := s.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		log.Warn(ctx, "Password reset for unknown email", zap.String("email", req.Email))
+		return nil
+	}
+
+	// The code proves ownership of the phone, so it only goes to a number the user verified;
+	// a mistyped or recycled number must not be able to take over the account
+	if !user.IsActive || !user.IsPhoneVerified() {
+		log.Warn(ctx, "Password reset for inactive user or user without verified phone", zap.String("user_id", user.ID.String()))
+		return nil
+	}
+
+	code, err := issueVerificationCode(ctx, s.codeRepo, user.ID, models.VerificationPurposePasswordReset, user.Phone, s.config.CodeTTL)
+	if err != nil {
+		log.Error(ctx, "Failed to issue password reset code", zap.Error(err), zap.String("user_id", user.ID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	if code == "" {
+		return nil
+	}
+
+	template := verificationCodeTemplate(s.config.TemplateName, s.config.TemplateLanguage, code)
+	if err := s.notifications.EnqueueTemplate(ctx, user.Phone, template); err != nil {
+		log.Error(ctx, "Failed to queue password reset code", zap.Error(err), zap.String("user_id", user.ID.String()))
+		span.RecordError(err)
+		return fmt.Errorf("failed to send password reset code")
+	}
+
+	log.Info(ctx, "Password reset code queued", zap.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+// ConfirmReset verifies a reset code and sets the new password. Every session of the user
+// is ended, since the old password may have been compromised.
+// Wrong codes count as failed logins for the email and the client IP, so new codes cannot be
+// requested over and over to keep guessing.
+func (s *passwordResetService) ConfirmReset(ctx context.Context, req *models.ConfirmPasswordResetRequest, clientIP string) error {
+	ctx, span := passwordResetTracer.Start(ctx, "PasswordResetService.ConfirmReset",
+		trace.WithAttributes(
+			attribute.String("email", req.Email),
+		),
+	)
+	defer span.End()
+
+	invalid := fmt.Errorf("invalid or expired reset code")
+
+	if err := s.loginThrottle.ReserveAttempt(ctx, req.Email, clientIP); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		log.Warn(ctx, "Password reset confirmation for unknown email", zap.String("email", req.Email))
+		s.loginThrottle.RecordFailure(ctx, req.Email, clientIP)
+		return invalid
+	}
+
+	if _, err := redeemVerificationCode(ctx, s.codeRepo, user.ID, models.VerificationPurposePasswordReset, req.Code, s.config.MaxAttempts); err != nil {
+		s.loginThrottle.RecordFailure(ctx, req.Email, clientIP)
+		span.RecordError(err)
+		return invalid
+	}
+
+	s.loginThrottle.RecordSuccess(ctx, req.Email, clientIP)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error(ctx, "Failed to hash password", zap.Error(err))
+		span.RecordError(err)
+		return fmt.Errorf("failed to process password: %w", err)
+	}
+
+	now := time.Now()
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, string(hashedPassword), now); err != nil {
+		log.Error(ctx, "Failed to store new password", zap.Error(err), zap.String("user_id", user.ID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		log.Error(ctx, "Failed to revoke refresh tokens", zap.Error(err), zap.String("user_id", user.ID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.userRepo.InvalidateTokens(ctx, user.ID, now); err != nil {
+		log.Error(ctx, "Failed to invalidate access tokens", zap.Error(err), zap.String("user_id", user.ID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	log.Info(ctx, "Password reset successfully", zap.String("user_id", user.ID.String()))
+
+	return nil
+}
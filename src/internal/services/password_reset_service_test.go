@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/config"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeUserRepository finds users by email in memory
+type fakeUserRepository struct {
+	repositories.UserRepository
+	users map[string]*models.User
+}
+
+func (r *fakeUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	if user, ok := r.users[email]; ok {
+		return user, nil
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+// fakeVerificationCodeRepository keeps the issued codes in memory
+type fakeVerificationCodeRepository struct {
+	repositories.VerificationCodeRepository
+	codes []*models.VerificationCode
+}
+
+func (r *fakeVerificationCodeRepository) FindLatest(ctx context.Context, userID uuid.UUID, purpose models.VerificationPurpose) (*models.VerificationCode, error) {
+	return nil, fmt.Errorf("verification code not found")
+}
+
+func (r *fakeVerificationCodeRepository) Create(ctx context.Context, code *models.VerificationCode) error {
+	r.codes = append(r.codes, code)
+	return nil
+}
+
+// fakeNotificationService records the phone numbers template messages are queued for
+type fakeNotificationService struct {
+	NotificationService
+	recipients []string
+}
+
+func (s *fakeNotificationService) EnqueueTemplate(ctx context.Context, to string, template *models.WhatsAppTemplateRequest) error {
+	s.recipients = append(s.recipients, to)
+	return nil
+}
+
+// requestReset requests a password reset for a user registered with the given phone verification
+func requestReset(t *testing.T, phoneVerifiedAt *time.Time) (*fakeVerificationCodeRepository, *fakeNotificationService) {
+	t.Helper()
+
+	user := &models.User{
+		ID:              uuid.New(),
+		Email:           "user@example.com",
+		Phone:           "+5511999999999",
+		IsActive:        true,
+		PhoneVerifiedAt: phoneVerifiedAt,
+	}
+	codeRepo := &fakeVerificationCodeRepository{}
+	notifications := &fakeNotificationService{}
+	service := NewPasswordResetService(
+		&fakeUserRepository{users: map[string]*models.User{user.Email: user}},
+		codeRepo,
+		nil,
+		notifications,
+		nil,
+		&config.PasswordResetConfig{CodeTTL: 10 * time.Minute, MaxAttempts: 5, TemplateName: "password_reset", TemplateLanguage: "en"},
+	)
+
+	ctx := log.Initialize(context.Background())
+	if err := service.RequestReset(ctx, &models.RequestPasswordResetRequest{Email: user.Email}); err != nil {
+		t.Fatalf("expected the generic success, got %v", err)
+	}
+	return codeRepo, notifications
+}
+
+func TestRequestResetSendsCodeToVerifiedPhone(t *testing.T) {
+	verifiedAt := time.Now().Add(-time.Hour)
+
+	codeRepo, notifications := requestReset(t, &verifiedAt)
+
+	if len(codeRepo.codes) != 1 {
+		t.Fatalf("expected 1 issued code, got %d", len(codeRepo.codes))
+	}
+	if len(notifications.recipients) != 1 || notifications.recipients[0] != "+5511999999999" {
+		t.Fatalf("expected the code to be sent to the verified phone, got %v", notifications.recipients)
+	}
+}
+
+func TestRequestResetIgnoresUnverifiedPhone(t *testing.T) {
+	codeRepo, notifications := requestReset(t, nil)
+
+	if len(codeRepo.codes) != 0 {
+		t.Errorf("expected no issued code, got %d", len(codeRepo.codes))
+	}
+	if len(notifications.recipients) != 0 {
+		t.Errorf("expected no message to the unverified phone, got %v", notifications.recipients)
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/config"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var phoneVerificationTracer = otel.Tracer("phone-verification-service")
+
+// PhoneVerificationService defines the interface for phone number verification operations
+type PhoneVerificationService interface {
+	SendCode(ctx context.Context, userID uuid.UUID) error
+	VerifyPhone(ctx context.Context, userID uuid.UUID, req *models.VerifyPhoneRequest) (*models.UserResponse, error)
+	InvalidateCodes(ctx context.Context, userID uuid.UUID) error
+}
+
+// phoneVerificationService implements PhoneVerificationService
+type phoneVerificationService struct {
+	userRepo      repositories.UserRepository
+	codeRepo      repositories.VerificationCodeRepository
+	notifications NotificationService
+	config        *config.PhoneVerificationConfig
+}
+
+// NewPhoneVerificationService creates a new instance of PhoneVerificationService.
+// notifications may be nil when the WhatsApp integration is not configured, which disables phone verification.
+func NewPhoneVerificationService(
+	userRepo repositories.UserRepository,
+	codeRepo repositories.VerificationCodeRepository,
+	notifications NotificationService,
+	config *config.PhoneVerificationConfig,
+) PhoneVerificationService {
+	return &phoneVerificationService{
+		userRepo:      userRepo,
+		codeRepo:      codeRepo,
+		notifications: notifications,
+		config:        config,
+	}
+}
+
+// SendCode sends a verification code over WhatsApp to the phone number of the user
+func (s *phoneVerificationService) SendCode(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := phoneVerificationTracer.Start(ctx, "PhoneVerificationService.SendCode",
+		trace.WithAttributes(
+			attribute.String("user_id", userID.String()),
+		),
+	)
+	defer span.End()
+
+	if s.notifications == nil {
+		log.Warn(ctx, "Phone verification requested but WhatsApp is not configured")
+		return fmt.Errorf("phone verification is not available")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if user.IsPhoneVerified() {
+		return fmt.Errorf("phone number is already verified")
+	}
+
+	code, err := issueVerificationCode(ctx, s.codeRepo, user.ID, models.VerificationPurposePhone, user.Phone, s.config.CodeTTL)
+	if err != nil {
+		log.Error(ctx, "Failed to issue phone verification code", zap.Error(err), zap.String("user_id", user.ID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	if code == "" {
+		return nil
+	}
+
+	template := verificationCodeTemplate(s.config.TemplateName, s.config.TemplateLanguage, code)
+	if err := s.notifications.EnqueueTemplate(ctx, user.Phone, template); err != nil {
+		log.Error(ctx, "Failed to queue phone verification code", zap.Error(err), zap.String("user_id", user.ID.String()))
+		span.RecordError(err)
+		return fmt.Errorf("failed to send phone verification code")
+	}
+
+	log.Info(ctx, "Phone verification code queued", zap.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+// VerifyPhone checks a verification code and marks the phone number of the user as verified
+func (s *phoneVerificationService) VerifyPhone(ctx context.Context, userID uuid.UUID, req *models.VerifyPhoneRequest) (*models.UserResponse, error) {
+	ctx, span := phoneVerificationTracer.Start(ctx, "PhoneVerificationService.VerifyPhone",
+		trace.WithAttributes(
+			attribute.String("user_id", userID.String()),
+		),
+	)
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if user.IsPhoneVerified() {
+		return nil, fmt.Errorf("phone number is already verified")
+	}
+
+	stored, err := redeemVerificationCode(ctx, s.codeRepo, user.ID, models.VerificationPurposePhone, req.Code, s.config.MaxAttempts)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// The code proves the phone number it was sent to, which may no longer be the user's
+	now := time.Now()
+	if err := s.userRepo.MarkPhoneVerified(ctx, user.ID, stored.Phone, now); err != nil {
+		log.Error(ctx, "Failed to mark phone as verified", zap.Error(err), zap.String("user_id", user.ID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	user.PhoneVerifiedAt = &now
+
+	log.Info(ctx, "Phone number verified", zap.String("user_id", user.ID.String()))
+
+	return user.ToResponse(), nil
+}
+
+// InvalidateCodes discards the unused verification codes of a user, which were sent to a phone number
+// the user no longer has
+func (s *phoneVerificationService) InvalidateCodes(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := phoneVerificationTracer.Start(ctx, "PhoneVerificationService.InvalidateCodes",
+		trace.WithAttributes(
+			attribute.String("user_id", userID.String()),
+		),
+	)
+	defer span.End()
+
+	if err := s.codeRepo.Invalidate(ctx, userID, models.VerificationPurposePhone, time.Now()); err != nil {
+		log.Error(ctx, "Failed to invalidate phone verification codes", zap.Error(err), zap.String("user_id", userID.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,433 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var queueTracer = otel.Tracer("queue-service")
+
+// QueueService defines the interface for queue management operations
+type QueueService interface {
+	CreateQueue(ctx context.Context, businessID uuid.UUID, req *models.CreateQueueRequest) (*models.QueueResponse, error)
+	GetQueueByID(ctx context.Context, businessID, queueID uuid.UUID) (*models.QueueResponse, error)
+	GetQueuesByBusiness(ctx context.Context, businessID uuid.UUID) ([]*models.QueueResponse, error)
+	UpdateQueue(ctx context.Context, businessID, queueID uuid.UUID, req *models.UpdateQueueRequest) (*models.QueueResponse, error)
+	DeleteQueue(ctx context.Context, businessID, queueID uuid.UUID) error
+	ListQueueTickets(ctx context.Context, businessID, queueID uuid.UUID, statuses []models.TicketStatus) ([]*models.TicketResponse, error)
+	CallNextTicket(ctx context.Context, businessID, queueID uuid.UUID) (*models.TicketResponse, error)
+	SkipTicket(ctx context.Context, businessID, queueID, ticketID uuid.UUID) (*models.TicketResponse, error)
+	RecallTicket(ctx context.Context, businessID, queueID, ticketID uuid.UUID) (*models.TicketResponse, error)
+	StartTicket(ctx context.Context, businessID, queueID, ticketID uuid.UUID) (*models.TicketResponse, error)
+	CompleteTicket(ctx context.Context, businessID, queueID, ticketID uuid.UUID) (*models.TicketResponse, error)
+}
+
+// queueService implements QueueService
+type queueService struct {
+	queueRepo    repositories.QueueRepository
+	ticketRepo   repositories.TicketRepository
+	businessRepo repositories.BusinessRepository
+	offeringRepo repositories.ServiceOfferingRepository
+	reputation   ReputationService
+}
+
+// NewQueueService creates a new instance of QueueService
+func NewQueueService(
+	queueRepo repositories.QueueRepository,
+	ticketRepo repositories.TicketRepository,
+	businessRepo repositories.BusinessRepository,
+	offeringRepo repositories.ServiceOfferingRepository,
+	reputation ReputationService,
+) QueueService {
+	return &queueService{
+		queueRepo:    queueRepo,
+		ticketRepo:   ticketRepo,
+		businessRepo: businessRepo,
+		offeringRepo: offeringRepo,
+		reputation:   reputation,
+	}
+}
+
+// CreateQueue creates a new queue for a business
+func (s *queueService) CreateQueue(ctx context.Context, businessID uuid.UUID, req *models.CreateQueueRequest) (*models.QueueResponse, error) {
+	ctx, span := queueTracer.Start(ctx, "QueueService.CreateQueue",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Creating new queue",
+		zap.String("business_id", businessID.String()),
+		zap.String("name", req.Name),
+	)
+
+	if err := s.validateServiceOffering(ctx, businessID, req.ServiceID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	queue := req.ToQueue(businessID)
+
+	if err := s.queueRepo.Create(ctx, queue); err != nil {
+		log.Error(ctx, "Failed to create queue in database",
+			zap.Error(err),
+			zap.String("business_id", businessID.String()),
+		)
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create queue: %w", err)
+	}
+
+	log.Info(ctx, "Queue created successfully",
+		zap.String("queue_id", queue.ID.String()),
+		zap.String("business_id", businessID.String()),
+	)
+
+	span.SetAttributes(attribute.String("queue_id", queue.ID.String()))
+
+	return queue.ToResponse(), nil
+}
+
+// GetQueueByID retrieves a queue
+func (s *queueService) GetQueueByID(ctx context.Context, businessID, queueID uuid.UUID) (*models.QueueResponse, error) {
+	ctx, span := queueTracer.Start(ctx, "QueueService.GetQueueByID",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("queue_id", queueID.String()),
+		),
+	)
+	defer span.End()
+
+	queue, err := s.getBusinessQueue(ctx, businessID, queueID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return queue.ToResponse(), nil
+}
+
+// GetQueuesByBusiness retrieves all queues
+func (s *queueService) GetQueuesByBusiness(ctx context.Context, businessID uuid.UUID) ([]*models.QueueResponse, error) {
+	ctx, span := queueTracer.Start(ctx, "QueueService.GetQueuesByBusiness",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+		),
+	)
+	defer span.End()
+
+	queues, err := s.queueRepo.FindByBusinessID(ctx, businessID)
+	if err != nil {
+		log.Error(ctx, "Failed to get queues by business",
+			zap.Error(err),
+			zap.String("business_id", businessID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	responses := make([]*models.QueueResponse, len(queues))
+	for i, queue := range queues {
+		responses[i] = queue.ToResponse()
+	}
+
+	span.SetAttributes(attribute.Int("queue_count", len(responses)))
+
+	return responses, nil
+}
+
+// UpdateQueue updates a queue
+func (s *queueService) UpdateQueue(ctx context.Context, businessID, queueID uuid.UUID, req *models.UpdateQueueRequest) (*models.QueueResponse, error) {
+	ctx, span := queueTracer.Start(ctx, "QueueService.UpdateQueue",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("queue_id", queueID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Updating queue",
+		zap.String("business_id", businessID.String()),
+		zap.String("queue_id", queueID.String()),
+	)
+
+	queue, err := s.getBusinessQueue(ctx, businessID, queueID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.validateServiceOffering(ctx, businessID, req.ServiceID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	queue.ApplyUpdateRequest(req)
+
+	if err := s.queueRepo.Update(ctx, queue); err != nil {
+		log.Error(ctx, "Failed to update queue in database",
+			zap.Error(err),
+			zap.String("queue_id", queueID.String()),
+		)
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to update queue: %w", err)
+	}
+
+	log.Info(ctx, "Queue updated successfully", zap.String("queue_id", queueID.String()))
+
+	return queue.ToResponse(), nil
+}
+
+// DeleteQueue deletes a queue
+func (s *queueService) DeleteQueue(ctx context.Context, businessID, queueID uuid.UUID) error {
+	ctx, span := queueTracer.Start(ctx, "QueueService.DeleteQueue",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("queue_id", queueID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Deleting queue",
+		zap.String("business_id", businessID.String()),
+		zap.String("queue_id", queueID.String()),
+	)
+
+	if _, err := s.getBusinessQueue(ctx, businessID, queueID); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.queueRepo.Delete(ctx, queueID); err != nil {
+		log.Error(ctx, "Failed to delete queue from database",
+			zap.Error(err),
+			zap.String("queue_id", queueID.String()),
+		)
+		span.RecordError(err)
+		return fmt.Errorf("failed to delete queue: %w", err)
+	}
+
+	log.Info(ctx, "Queue deleted successfully", zap.String("queue_id", queueID.String()))
+
+	return nil
+}
+
+// ListQueueTickets lists the tickets of a queue, optionally filtered by status
+func (s *queueService) ListQueueTickets(ctx context.Context, businessID, queueID uuid.UUID, statuses []models.TicketStatus) ([]*models.TicketResponse, error) {
+	ctx, span := queueTracer.Start(ctx, "QueueService.ListQueueTickets",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("queue_id", queueID.String()),
+		),
+	)
+	defer span.End()
+
+	if _, err := s.getBusinessQueue(ctx, businessID, queueID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	tickets, err := s.ticketRepo.FindByQueueID(ctx, queueID, statuses)
+	if err != nil {
+		log.Error(ctx, "Failed to list queue tickets",
+			zap.Error(err),
+			zap.String("queue_id", queueID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	responses := make([]*models.TicketResponse, len(tickets))
+	for i, ticket := range tickets {
+		responses[i] = ticket.ToResponse()
+	}
+	s.reputation.AttachCustomerReputation(ctx, responses)
+
+	span.SetAttributes(attribute.Int("ticket_count", len(responses)))
+
+	return responses, nil
+}
+
+// CallNextTicket calls the first waiting ticket of a queue
+func (s *queueService) CallNextTicket(ctx context.Context, businessID, queueID uuid.UUID) (*models.TicketResponse, error) {
+	ctx, span := queueTracer.Start(ctx, "QueueService.CallNextTicket",
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("queue_id", queueID.String()),
+		),
+	)
+	defer span.End()
+
+	if _, err := s.getBusinessQueue(ctx, businessID, queueID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.CallNext(ctx, queueID, time.Now())
+	if err != nil {
+		log.Warn(ctx, "Failed to call next ticket",
+			zap.Error(err),
+			zap.String("queue_id", queueID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Ticket called",
+		zap.String("ticket_id", ticket.ID.String()),
+		zap.Int("number", ticket.Number),
+	)
+
+	span.SetAttributes(attribute.String("ticket_id", ticket.ID.String()))
+
+	return s.consoleTicketResponse(ctx, ticket), nil
+}
+
+// SkipTicket marks a called ticket as skipped so the next customer can be served
+func (s *queueService) SkipTicket(ctx context.Context, businessID, queueID, ticketID uuid.UUID) (*models.TicketResponse, error) {
+	return s.transitionTicket(ctx, "QueueService.SkipTicket", businessID, queueID, ticketID, models.TicketStatusSkipped)
+}
+
+// RecallTicket calls a skipped ticket again
+func (s *queueService) RecallTicket(ctx context.Context, businessID, queueID, ticketID uuid.UUID) (*models.TicketResponse, error) {
+	return s.transitionTicket(ctx, "QueueService.RecallTicket", businessID, queueID, ticketID, models.TicketStatusCalled)
+}
+
+// StartTicket marks a called ticket as in service
+func (s *queueService) StartTicket(ctx context.Context, businessID, queueID, ticketID uuid.UUID) (*models.TicketResponse, error) {
+	return s.transitionTicket(ctx, "QueueService.StartTicket", businessID, queueID, ticketID, models.TicketStatusInService)
+}
+
+// CompleteTicket marks an in-service ticket as done
+func (s *queueService) CompleteTicket(ctx context.Context, businessID, queueID, ticketID uuid.UUID) (*models.TicketResponse, error) {
+	return s.transitionTicket(ctx, "QueueService.CompleteTicket", businessID, queueID, ticketID, models.TicketStatusDone)
+}
+
+// transitionTicket applies a console action to a ticket of a queue.
+// The update only succeeds if the ticket is still in the status it was read in.
+func (s *queueService) transitionTicket(ctx context.Context, spanName string, businessID, queueID, ticketID uuid.UUID, next models.TicketStatus) (*models.TicketResponse, error) {
+	ctx, span := queueTracer.Start(ctx, spanName,
+		trace.WithAttributes(
+			attribute.String("business_id", businessID.String()),
+			attribute.String("queue_id", queueID.String()),
+			attribute.String("ticket_id", ticketID.String()),
+			attribute.String("next_status", string(next)),
+		),
+	)
+	defer span.End()
+
+	if _, err := s.getBusinessQueue(ctx, businessID, queueID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		log.Error(ctx, "Failed to find ticket", zap.Error(err), zap.String("ticket_id", ticketID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if ticket.QueueID != queueID {
+		log.Warn(ctx, "Ticket does not belong to queue",
+			zap.String("ticket_id", ticketID.String()),
+			zap.String("queue_id", queueID.String()),
+		)
+		return nil, fmt.Errorf("ticket not found")
+	}
+
+	fromStatus := ticket.Status
+	if err := ticket.TransitionTo(next, time.Now()); err != nil {
+		log.Warn(ctx, "Invalid ticket transition",
+			zap.String("ticket_id", ticketID.String()),
+			zap.String("from", string(fromStatus)),
+			zap.String("to", string(next)),
+		)
+		return nil, err
+	}
+
+	if err := s.ticketRepo.UpdateStatus(ctx, ticket, fromStatus); err != nil {
+		log.Warn(ctx, "Failed to update ticket status",
+			zap.Error(err),
+			zap.String("ticket_id", ticketID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Ticket status updated",
+		zap.String("ticket_id", ticketID.String()),
+		zap.String("from", string(fromStatus)),
+		zap.String("to", string(next)),
+	)
+
+	if next.IsFinal() {
+		// Reputation is best effort: the console action already succeeded
+		_, _ = s.reputation.RecalculateReputation(ctx, ticket.CustomerID)
+	}
+
+	return s.consoleTicketResponse(ctx, ticket), nil
+}
+
+// consoleTicketResponse builds the ticket response shown to the business staff,
+// including the customer's reputation
+func (s *queueService) consoleTicketResponse(ctx context.Context, ticket *models.Ticket) *models.TicketResponse {
+	response := ticket.ToResponse()
+	s.reputation.AttachCustomerReputation(ctx, []*models.TicketResponse{response})
+	return response
+}
+
+// validateServiceOffering ensures an optional service offering belongs to the business
+func (s *queueService) validateServiceOffering(ctx context.Context, businessID uuid.UUID, serviceID *uuid.UUID) error {
+	if serviceID == nil {
+		return nil
+	}
+
+	offering, err := s.offeringRepo.FindByID(ctx, *serviceID)
+	if err != nil {
+		log.Warn(ctx, "Failed to find service offering", zap.Error(err), zap.String("service_id", serviceID.String()))
+		return err
+	}
+
+	if offering.BusinessID != businessID {
+		log.Warn(ctx, "Service offering does not belong to business",
+			zap.String("service_id", serviceID.String()),
+			zap.String("business_id", businessID.String()),
+		)
+		return fmt.Errorf("service offering not found")
+	}
+
+	return nil
+}
+
+// getBusinessQueue loads a queue and verifies it belongs to the business
+func (s *queueService) getBusinessQueue(ctx context.Context, businessID, queueID uuid.UUID) (*models.Queue, error) {
+	queue, err := s.queueRepo.FindByID(ctx, queueID)
+	if err != nil {
+		log.Error(ctx, "Failed to find queue",
+			zap.Error(err),
+			zap.String("queue_id", queueID.String()),
+		)
+		return nil, err
+	}
+
+	if queue.BusinessID != businessID {
+		log.Warn(ctx, "Queue does not belong to business",
+			zap.String("queue_id", queueID.String()),
+			zap.String("business_id", businessID.String()),
+		)
+		return nil, fmt.Errorf("queue not found")
+	}
+
+	return queue, nil
+}
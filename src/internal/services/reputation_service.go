@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var reputationTracer = otel.Tracer("reputation-service")
+
+// ReputationService defines the interface for customer reputation operations
+type ReputationService interface {
+	RecalculateReputation(ctx context.Context, customerID uuid.UUID) (*models.Reputation, error)
+	AttachCustomerReputation(ctx context.Context, tickets []*models.TicketResponse)
+}
+
+// reputationService implements ReputationService
+type reputationService struct {
+	ticketRepo repositories.TicketRepository
+	userRepo   repositories.UserRepository
+}
+
+// NewReputationService creates a new instance of ReputationService
+func NewReputationService(ticketRepo repositories.TicketRepository, userRepo repositories.UserRepository) ReputationService {
+	return &reputationService{
+		ticketRepo: ticketRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// RecalculateReputation recomputes a customer's reputation from their ticket history and stores it
+func (s *reputationService) RecalculateReputation(ctx context.Context, customerID uuid.UUID) (*models.Reputation, error) {
+	ctx, span := reputationTracer.Start(ctx, "ReputationService.RecalculateReputation",
+		trace.WithAttributes(
+			attribute.String("customer_id", customerID.String()),
+		),
+	)
+	defer span.End()
+
+	outcomes, err := s.ticketRepo.CountCustomerOutcomes(ctx, customerID)
+	if err != nil {
+		log.Error(ctx, "Failed to count customer ticket outcomes", zap.Error(err), zap.String("customer_id", customerID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	reputation := models.NewReputation(outcomes, time.Now())
+
+	if err := s.userRepo.UpdateReputation(ctx, customerID, reputation); err != nil {
+		log.Error(ctx, "Failed to store customer reputation", zap.Error(err), zap.String("customer_id", customerID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Customer reputation recalculated",
+		zap.String("customer_id", customerID.String()),
+		zap.Int("score", reputation.Score),
+	)
+
+	span.SetAttributes(attribute.Int("score", reputation.Score))
+
+	return &reputation, nil
+}
+
+// AttachCustomerReputation fills in the current reputation score of each ticket's customer.
+// Failures are logged and leave the tickets without a score.
+func (s *reputationService) AttachCustomerReputation(ctx context.Context, tickets []*models.TicketResponse) {
+	if len(tickets) == 0 {
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(tickets))
+	for _, ticket := range tickets {
+		ids = append(ids, ticket.CustomerID)
+	}
+
+	customers, err := s.userRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		log.Warn(ctx, "Failed to load customer reputations", zap.Error(err))
+		return
+	}
+
+	scores := make(map[uuid.UUID]int, len(customers))
+	for _, customer := range customers {
+		scores[customer.ID] = customer.Reputation.Score
+	}
+
+	for _, ticket := range tickets {
+		if score, ok := scores[ticket.CustomerID]; ok {
+			ticket.CustomerReputation = &score
+		}
+	}
+}
@@ -0,0 +1,672 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var ticketTracer = otel.Tracer("ticket-service")
+
+// TicketService defines the interface for customer ticket operations
+type TicketService interface {
+	JoinQueue(ctx context.Context, customerID, queueID uuid.UUID) (*models.TicketPositionResponse, error)
+	GetMyTickets(ctx context.Context, customerID uuid.UUID) ([]*models.TicketResponse, error)
+	GetTicketPosition(ctx context.Context, customerID, ticketID uuid.UUID) (*models.TicketPositionResponse, error)
+	CheckIn(ctx context.Context, customerID, ticketID uuid.UUID, req *models.CheckInRequest) (*models.CheckInResponse, error)
+	GetAvailability(ctx context.Context, queueID uuid.UUID, date string) (*models.AvailabilityResponse, error)
+	BookAppointment(ctx context.Context, customerID, queueID uuid.UUID, req *models.BookAppointmentRequest) (*models.TicketPositionResponse, error)
+	CancelTicket(ctx context.Context, customerID, ticketID uuid.UUID) (*models.TicketResponse, error)
+	RescheduleTicket(ctx context.Context, customerID, ticketID uuid.UUID, req *models.RescheduleTicketRequest) (*models.TicketPositionResponse, error)
+}
+
+// ticketService implements TicketService
+type ticketService struct {
+	ticketRepo   repositories.TicketRepository
+	queueRepo    repositories.QueueRepository
+	businessRepo repositories.BusinessRepository
+	userRepo     repositories.UserRepository
+	offeringRepo repositories.ServiceOfferingRepository
+	hoursRepo    repositories.OpeningHoursRepository
+	closureRepo  repositories.BusinessClosureRepository
+	reputation   ReputationService
+}
+
+// NewTicketService creates a new instance of TicketService
+func NewTicketService(
+	ticketRepo repositories.TicketRepository,
+	queueRepo repositories.QueueRepository,
+	businessRepo repositories.BusinessRepository,
+	userRepo repositories.UserRepository,
+	offeringRepo repositories.ServiceOfferingRepository,
+	hoursRepo repositories.OpeningHoursRepository,
+	closureRepo repositories.BusinessClosureRepository,
+	reputation ReputationService,
+) TicketService {
+	return &ticketService{
+		ticketRepo:   ticketRepo,
+		queueRepo:    queueRepo,
+		businessRepo: businessRepo,
+		userRepo:     userRepo,
+		offeringRepo: offeringRepo,
+		hoursRepo:    hoursRepo,
+		closureRepo:  closureRepo,
+		reputation:   reputation,
+	}
+}
+
+// JoinQueue issues a new ticket for a customer in an active queue
+func (s *ticketService) JoinQueue(ctx context.Context, customerID, queueID uuid.UUID) (*models.TicketPositionResponse, error) {
+	ctx, span := ticketTracer.Start(ctx, "TicketService.JoinQueue",
+		trace.WithAttributes(
+			attribute.String("customer_id", customerID.String()),
+			attribute.String("queue_id", queueID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Customer joining queue",
+		zap.String("customer_id", customerID.String()),
+		zap.String("queue_id", queueID.String()),
+	)
+
+	queue, business, err := s.loadJoinableQueue(ctx, customerID, queueID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	schedule, err := loadBusinessSchedule(ctx, s.hoursRepo, s.closureRepo, business)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if !schedule.IsOpenAt(time.Now()) {
+		log.Warn(ctx, "Business is closed", zap.String("business_id", business.ID.String()))
+		return nil, fmt.Errorf("business is closed")
+	}
+
+	ticket := models.NewTicket(queue, customerID)
+
+	if err := s.ticketRepo.Create(ctx, ticket); err != nil {
+		log.Error(ctx, "Failed to create ticket",
+			zap.Error(err),
+			zap.String("queue_id", queueID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Customer joined queue",
+		zap.String("ticket_id", ticket.ID.String()),
+		zap.Int("number", ticket.Number),
+	)
+
+	span.SetAttributes(attribute.String("ticket_id", ticket.ID.String()))
+
+	return s.buildPosition(ctx, ticket, queue)
+}
+
+// GetMyTickets lists the active tickets of a customer
+func (s *ticketService) GetMyTickets(ctx context.Context, customerID uuid.UUID) ([]*models.TicketResponse, error) {
+	ctx, span := ticketTracer.Start(ctx, "TicketService.GetMyTickets",
+		trace.WithAttributes(
+			attribute.String("customer_id", customerID.String()),
+		),
+	)
+	defer span.End()
+
+	tickets, err := s.ticketRepo.FindActiveByCustomerID(ctx, customerID)
+	if err != nil {
+		log.Error(ctx, "Failed to get customer tickets",
+			zap.Error(err),
+			zap.String("customer_id", customerID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	responses := make([]*models.TicketResponse, len(tickets))
+	for i, ticket := range tickets {
+		responses[i] = ticket.ToResponse()
+	}
+
+	return responses, nil
+}
+
+// GetTicketPosition returns the current position and estimated start of a customer's ticket
+func (s *ticketService) GetTicketPosition(ctx context.Context, customerID, ticketID uuid.UUID) (*models.TicketPositionResponse, error) {
+	ctx, span := ticketTracer.Start(ctx, "TicketService.GetTicketPosition",
+		trace.WithAttributes(
+			attribute.String("customer_id", customerID.String()),
+			attribute.String("ticket_id", ticketID.String()),
+		),
+	)
+	defer span.End()
+
+	ticket, err := s.getCustomerTicket(ctx, customerID, ticketID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	queue, err := s.queueRepo.FindByID(ctx, ticket.QueueID)
+	if err != nil {
+		log.Error(ctx, "Failed to find queue", zap.Error(err), zap.String("queue_id", ticket.QueueID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return s.buildPosition(ctx, ticket, queue)
+}
+
+// CheckIn validates the customer's distance to the business against the service radius
+// and records the result on the ticket
+func (s *ticketService) CheckIn(ctx context.Context, customerID, ticketID uuid.UUID, req *models.CheckInRequest) (*models.CheckInResponse, error) {
+	ctx, span := ticketTracer.Start(ctx, "TicketService.CheckIn",
+		trace.WithAttributes(
+			attribute.String("customer_id", customerID.String()),
+			attribute.String("ticket_id", ticketID.String()),
+		),
+	)
+	defer span.End()
+
+	ticket, err := s.getCustomerTicket(ctx, customerID, ticketID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if !ticket.Status.IsActive() {
+		log.Warn(ctx, "Check-in on inactive ticket",
+			zap.String("ticket_id", ticketID.String()),
+			zap.String("status", string(ticket.Status)),
+		)
+		return nil, fmt.Errorf("ticket is no longer active")
+	}
+
+	business, err := s.businessRepo.FindByID(ctx, ticket.BusinessID)
+	if err != nil {
+		log.Error(ctx, "Failed to find business", zap.Error(err), zap.String("business_id", ticket.BusinessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if !business.HasLocation() {
+		log.Warn(ctx, "Business location is not configured", zap.String("business_id", business.ID.String()))
+		return nil, fmt.Errorf("business location is not configured")
+	}
+
+	queue, err := s.queueRepo.FindByID(ctx, ticket.QueueID)
+	if err != nil {
+		log.Error(ctx, "Failed to find queue", zap.Error(err), zap.String("queue_id", ticket.QueueID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	radius := 0
+	if offering := s.queueServiceOffering(ctx, queue); offering != nil {
+		radius = offering.CheckInRadiusMeters
+	}
+
+	distance := haversineDistanceMeters(*req.Latitude, *req.Longitude, *business.Latitude, *business.Longitude)
+
+	status := models.TicketCheckInAccepted
+	if radius > 0 && distance > float64(radius) {
+		status = models.TicketCheckInRejected
+	}
+
+	ticket.RecordCheckIn(status, distance, time.Now())
+
+	if err := s.ticketRepo.UpdateCheckIn(ctx, ticket); err != nil {
+		log.Error(ctx, "Failed to record check-in", zap.Error(err), zap.String("ticket_id", ticketID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Customer check-in recorded",
+		zap.String("ticket_id", ticketID.String()),
+		zap.String("status", string(status)),
+		zap.Float64("distance_meters", distance),
+		zap.Int("radius_meters", radius),
+	)
+
+	span.SetAttributes(
+		attribute.String("check_in_status", string(status)),
+		attribute.Float64("distance_meters", distance),
+	)
+
+	return &models.CheckInResponse{
+		Ticket:         ticket.ToResponse(),
+		Status:         status,
+		DistanceMeters: distance,
+		RadiusMeters:   radius,
+	}, nil
+}
+
+// loadJoinableQueue verifies the customer may take a ticket and loads the queue and its business,
+// which must both be accepting customers and may require the customer's phone to be verified
+func (s *ticketService) loadJoinableQueue(ctx context.Context, customerID, queueID uuid.UUID) (*models.Queue, *models.Business, error) {
+	customer, err := s.userRepo.FindByID(ctx, customerID)
+	if err != nil {
+		log.Error(ctx, "Failed to find customer", zap.Error(err), zap.String("customer_id", customerID.String()))
+		return nil, nil, err
+	}
+
+	if !customer.HasRole(models.RoleCustomer) {
+		log.Warn(ctx, "User does not have Customer role", zap.String("customer_id", customerID.String()))
+		return nil, nil, fmt.Errorf("user must have Customer role to join a queue")
+	}
+
+	queue, err := s.queueRepo.FindByID(ctx, queueID)
+	if err != nil {
+		log.Error(ctx, "Failed to find queue", zap.Error(err), zap.String("queue_id", queueID.String()))
+		return nil, nil, err
+	}
+
+	business, err := s.businessRepo.FindByID(ctx, queue.BusinessID)
+	if err != nil {
+		log.Error(ctx, "Failed to find business", zap.Error(err), zap.String("business_id", queue.BusinessID.String()))
+		return nil, nil, err
+	}
+
+	if !queue.IsActive || !business.IsActive {
+		log.Warn(ctx, "Queue is not accepting customers",
+			zap.String("queue_id", queueID.String()),
+			zap.Bool("queue_active", queue.IsActive),
+			zap.Bool("business_active", business.IsActive),
+		)
+		return nil, nil, fmt.Errorf("queue is not accepting customers")
+	}
+
+	if business.RequireVerifiedPhone && !customer.IsPhoneVerified() {
+		log.Warn(ctx, "Business requires a verified phone number",
+			zap.String("business_id", business.ID.String()),
+			zap.String("customer_id", customerID.String()),
+		)
+		return nil, nil, fmt.Errorf("verified phone number required")
+	}
+
+	return queue, business, nil
+}
+
+// GetAvailability lists the free appointment slots of a queue on a day, given as YYYY-MM-DD
+// in the business timezone. Slots last the duration of the queue's service and must fit in
+// the opening hours without overlapping a booked appointment.
+func (s *ticketService) GetAvailability(ctx context.Context, queueID uuid.UUID, date string) (*models.AvailabilityResponse, error) {
+	ctx, span := ticketTracer.Start(ctx, "TicketService.GetAvailability",
+		trace.WithAttributes(
+			attribute.String("queue_id", queueID.String()),
+			attribute.String("date", date),
+		),
+	)
+	defer span.End()
+
+	queue, err := s.queueRepo.FindByID(ctx, queueID)
+	if err != nil {
+		log.Error(ctx, "Failed to find queue", zap.Error(err), zap.String("queue_id", queueID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	business, err := s.businessRepo.FindByID(ctx, queue.BusinessID)
+	if err != nil {
+		log.Error(ctx, "Failed to find business", zap.Error(err), zap.String("business_id", queue.BusinessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	schedule, err := loadBusinessSchedule(ctx, s.hoursRepo, s.closureRepo, business)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", date, schedule.Location)
+	if err != nil {
+		log.Warn(ctx, "Invalid availability date", zap.String("date", date))
+		return nil, fmt.Errorf("invalid date")
+	}
+
+	duration := time.Duration(s.serviceMinutes(ctx, queue)) * time.Minute
+	response := &models.AvailabilityResponse{
+		QueueID:         queueID,
+		Date:            date,
+		Timezone:        business.Timezone,
+		DurationMinutes: int(duration.Minutes()),
+		Slots:           []models.TimeSlot{},
+	}
+
+	intervals := schedule.IntervalsOn(day)
+	if !queue.IsActive || !business.IsActive || len(intervals) == 0 {
+		return response, nil
+	}
+
+	booked, err := s.ticketRepo.FindScheduledInRange(ctx, queueID, intervals[0].StartAt, intervals[len(intervals)-1].EndAt)
+	if err != nil {
+		log.Error(ctx, "Failed to get booked appointments", zap.Error(err), zap.String("queue_id", queueID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, interval := range intervals {
+		for start := interval.StartAt; !start.Add(duration).After(interval.EndAt); start = start.Add(duration) {
+			slot := models.TimeSlot{StartAt: start, EndAt: start.Add(duration)}
+			if start.Before(now) || overlapsAppointment(slot, booked) {
+				continue
+			}
+			response.Slots = append(response.Slots, slot)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("slot_count", len(response.Slots)))
+
+	return response, nil
+}
+
+// BookAppointment reserves a time slot in a queue for the customer
+func (s *ticketService) BookAppointment(ctx context.Context, customerID, queueID uuid.UUID, req *models.BookAppointmentRequest) (*models.TicketPositionResponse, error) {
+	ctx, span := ticketTracer.Start(ctx, "TicketService.BookAppointment",
+		trace.WithAttributes(
+			attribute.String("customer_id", customerID.String()),
+			attribute.String("queue_id", queueID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Customer booking appointment",
+		zap.String("customer_id", customerID.String()),
+		zap.String("queue_id", queueID.String()),
+		zap.Time("scheduled_at", req.ScheduledAt),
+	)
+
+	queue, business, err := s.loadJoinableQueue(ctx, customerID, queueID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	slot, err := s.appointmentSlot(ctx, queue, business, req.ScheduledAt)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	ticket := models.NewAppointmentTicket(queue, customerID, slot)
+
+	if err := s.ticketRepo.Create(ctx, ticket); err != nil {
+		log.Warn(ctx, "Failed to book appointment",
+			zap.Error(err),
+			zap.String("queue_id", queueID.String()),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Appointment booked",
+		zap.String("ticket_id", ticket.ID.String()),
+		zap.Int("number", ticket.Number),
+		zap.Time("scheduled_at", slot.StartAt),
+	)
+
+	span.SetAttributes(attribute.String("ticket_id", ticket.ID.String()))
+
+	return s.buildPosition(ctx, ticket, queue)
+}
+
+// CancelTicket cancels a customer's ticket. Cancelling after the business cancellation
+// window, or once the ticket has been called, counts against the customer's reputation.
+func (s *ticketService) CancelTicket(ctx context.Context, customerID, ticketID uuid.UUID) (*models.TicketResponse, error) {
+	ctx, span := ticketTracer.Start(ctx, "TicketService.CancelTicket",
+		trace.WithAttributes(
+			attribute.String("customer_id", customerID.String()),
+			attribute.String("ticket_id", ticketID.String()),
+		),
+	)
+	defer span.End()
+
+	ticket, err := s.getCustomerTicket(ctx, customerID, ticketID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if !ticket.Status.CanTransitionTo(models.TicketStatusCancelled) {
+		log.Warn(ctx, "Ticket can no longer be cancelled",
+			zap.String("ticket_id", ticketID.String()),
+			zap.String("status", string(ticket.Status)),
+		)
+		return nil, fmt.Errorf("ticket can no longer be cancelled")
+	}
+
+	business, err := s.businessRepo.FindByID(ctx, ticket.BusinessID)
+	if err != nil {
+		log.Error(ctx, "Failed to find business", zap.Error(err), zap.String("business_id", ticket.BusinessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	fromStatus := ticket.Status
+	late := business.IsLateChange(ticket, now)
+
+	if err := ticket.Cancel(now, late); err != nil {
+		return nil, err
+	}
+
+	if err := s.ticketRepo.Cancel(ctx, ticket, fromStatus); err != nil {
+		log.Error(ctx, "Failed to cancel ticket", zap.Error(err), zap.String("ticket_id", ticketID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Customer cancelled ticket",
+		zap.String("ticket_id", ticketID.String()),
+		zap.Bool("late_cancellation", late),
+	)
+
+	span.SetAttributes(attribute.Bool("late_cancellation", late))
+
+	if late {
+		// Reputation is best effort: the cancellation already succeeded
+		_, _ = s.reputation.RecalculateReputation(ctx, customerID)
+	}
+
+	return ticket.ToResponse(), nil
+}
+
+// RescheduleTicket moves a customer's waiting ticket to another time slot. Appointments can only
+// be moved before the business cancellation window; walk-in tickets become appointments.
+func (s *ticketService) RescheduleTicket(ctx context.Context, customerID, ticketID uuid.UUID, req *models.RescheduleTicketRequest) (*models.TicketPositionResponse, error) {
+	ctx, span := ticketTracer.Start(ctx, "TicketService.RescheduleTicket",
+		trace.WithAttributes(
+			attribute.String("customer_id", customerID.String()),
+			attribute.String("ticket_id", ticketID.String()),
+		),
+	)
+	defer span.End()
+
+	log.Info(ctx, "Customer rescheduling ticket",
+		zap.String("ticket_id", ticketID.String()),
+		zap.Time("scheduled_at", req.ScheduledAt),
+	)
+
+	ticket, err := s.getCustomerTicket(ctx, customerID, ticketID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if ticket.Status != models.TicketStatusWaiting {
+		log.Warn(ctx, "Only waiting tickets can be rescheduled",
+			zap.String("ticket_id", ticketID.String()),
+			zap.String("status", string(ticket.Status)),
+		)
+		return nil, fmt.Errorf("only waiting tickets can be rescheduled")
+	}
+
+	queue, business, err := s.loadJoinableQueue(ctx, customerID, ticket.QueueID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	if business.IsLateChange(ticket, now) {
+		log.Warn(ctx, "Reschedule is past the cancellation window",
+			zap.String("ticket_id", ticketID.String()),
+			zap.Int("notice_minutes", business.CancellationNoticeMinutes),
+		)
+		return nil, fmt.Errorf("too late to reschedule this ticket")
+	}
+
+	slot, err := s.appointmentSlot(ctx, queue, business, req.ScheduledAt)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	ticket.Reschedule(slot, now)
+
+	if err := s.ticketRepo.Reschedule(ctx, ticket); err != nil {
+		log.Warn(ctx, "Failed to reschedule ticket", zap.Error(err), zap.String("ticket_id", ticketID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "Customer rescheduled ticket",
+		zap.String("ticket_id", ticketID.String()),
+		zap.Time("scheduled_at", slot.StartAt),
+	)
+
+	return s.buildPosition(ctx, ticket, queue)
+}
+
+// appointmentSlot builds the slot starting at scheduledAt for the queue's service and
+// verifies it is in the future and within the business opening hours
+func (s *ticketService) appointmentSlot(ctx context.Context, queue *models.Queue, business *models.Business, scheduledAt time.Time) (models.TimeSlot, error) {
+	if !scheduledAt.After(time.Now()) {
+		log.Warn(ctx, "Appointment is not in the future", zap.Time("scheduled_at", scheduledAt))
+		return models.TimeSlot{}, fmt.Errorf("appointment must be in the future")
+	}
+
+	schedule, err := loadBusinessSchedule(ctx, s.hoursRepo, s.closureRepo, business)
+	if err != nil {
+		return models.TimeSlot{}, err
+	}
+
+	duration := time.Duration(s.serviceMinutes(ctx, queue)) * time.Minute
+	slot := models.TimeSlot{StartAt: scheduledAt, EndAt: scheduledAt.Add(duration)}
+
+	if !schedule.Covers(slot) {
+		log.Warn(ctx, "Appointment is outside opening hours",
+			zap.String("business_id", business.ID.String()),
+			zap.Time("scheduled_at", scheduledAt),
+		)
+		return models.TimeSlot{}, fmt.Errorf("appointment is outside opening hours")
+	}
+
+	return slot, nil
+}
+
+// overlapsAppointment checks if a slot overlaps any of the booked appointments
+func overlapsAppointment(slot models.TimeSlot, booked []*models.Ticket) bool {
+	for _, ticket := range booked {
+		if slot.Overlaps(models.TimeSlot{StartAt: *ticket.ScheduledAt, EndAt: *ticket.ScheduledUntil}) {
+			return true
+		}
+	}
+	return false
+}
+
+// getCustomerTicket loads a ticket and verifies it belongs to the customer
+func (s *ticketService) getCustomerTicket(ctx context.Context, customerID, ticketID uuid.UUID) (*models.Ticket, error) {
+	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		log.Error(ctx, "Failed to find ticket", zap.Error(err), zap.String("ticket_id", ticketID.String()))
+		return nil, err
+	}
+
+	if ticket.CustomerID != customerID {
+		log.Warn(ctx, "Ticket does not belong to customer",
+			zap.String("ticket_id", ticketID.String()),
+			zap.String("customer_id", customerID.String()),
+		)
+		return nil, fmt.Errorf("ticket not found")
+	}
+
+	return ticket, nil
+}
+
+// queueServiceOffering loads the service offering linked to a queue,
+// returning nil when the queue has none or it cannot be loaded
+func (s *ticketService) queueServiceOffering(ctx context.Context, queue *models.Queue) *models.ServiceOffering {
+	if queue.ServiceID == nil {
+		return nil
+	}
+
+	offering, err := s.offeringRepo.FindByID(ctx, *queue.ServiceID)
+	if err != nil {
+		log.Warn(ctx, "Failed to load queue service offering",
+			zap.Error(err),
+			zap.String("queue_id", queue.ID.String()),
+		)
+		return nil
+	}
+
+	return offering
+}
+
+// serviceMinutes returns the expected duration of one ticket in the queue,
+// preferring the average duration of the linked service offering
+func (s *ticketService) serviceMinutes(ctx context.Context, queue *models.Queue) int {
+	if offering := s.queueServiceOffering(ctx, queue); offering != nil {
+		return offering.AverageDurationMinutes
+	}
+	return queue.AverageServiceMinutes
+}
+
+// buildPosition computes how many tickets will be served before the ticket and when it is
+// expected to be called, merging walk-ins with appointments
+func (s *ticketService) buildPosition(ctx context.Context, ticket *models.Ticket, queue *models.Queue) (*models.TicketPositionResponse, error) {
+	now := time.Now()
+	response := &models.TicketPositionResponse{
+		Ticket:           ticket.ToResponse(),
+		EstimatedStartAt: now,
+	}
+
+	if ticket.Status != models.TicketStatusWaiting {
+		return response, nil
+	}
+
+	waiting, err := s.ticketRepo.FindByQueueID(ctx, ticket.QueueID, []models.TicketStatus{models.TicketStatusWaiting})
+	if err != nil {
+		log.Error(ctx, "Failed to list waiting tickets", zap.Error(err), zap.String("queue_id", ticket.QueueID.String()))
+		return nil, err
+	}
+
+	serviceTime := time.Duration(s.serviceMinutes(ctx, queue)) * time.Minute
+	estimate, ok := models.EstimateStart(waiting, ticket.ID, now, serviceTime)
+	if !ok {
+		// The ticket was called between both reads
+		return response, nil
+	}
+
+	response.Position = estimate.Position
+	response.TicketsAhead = estimate.Ahead
+	response.EstimatedWaitMinutes = int(estimate.StartAt.Sub(now).Minutes())
+	response.EstimatedStartAt = estimate.StartAt
+
+	return response, nil
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"easy-queue-go/src/internal/config"
+	"easy-queue-go/src/internal/models"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing or verifying tokens
+const minRSAKeyBits = 2048
+
+// TokenKeySet holds the keys that sign and verify JWTs.
+// New tokens are signed with the active key and carry its ID in the kid header; every key in the set
+// verifies tokens, so a retired key keeps validating the tokens it signed until they expire.
+// Without asymmetric keys, tokens are signed with the HS256 secret. When both are configured, tokens
+// without a kid are verified with the secret only until the configured legacy cutoff, so a deployment
+// can move to asymmetric keys without logging every user out, but a leaked secret stops working after it.
+type TokenKeySet struct {
+	active      *tokenKey
+	keys        map[string]*tokenKey
+	secret      []byte
+	secretUntil time.Time // End of the HS256 fallback once asymmetric keys are configured, zero for none
+}
+
+// tokenKey is a single asymmetric key of the set
+type tokenKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey interface{} // nil for keys kept for verification only
+	publicKey  interface{}
+}
+
+// LoadTokenKeySet loads the signing and verification keys described by the JWT configuration.
+// Each file <kid>.pem in the keys directory holds an RSA or Ed25519 key, either private or public.
+// Public keys can only verify, which is how a key is kept after it stopped signing.
+func LoadTokenKeySet(cfg *config.JWTConfig) (*TokenKeySet, error) {
+	set := &TokenKeySet{
+		keys: make(map[string]*tokenKey),
+	}
+
+	if cfg.Secret != "" {
+		set.secret = []byte(cfg.Secret)
+	}
+
+	if cfg.KeysDir == "" {
+		return set, nil
+	}
+
+	if cfg.LegacySecretUntil != nil {
+		set.secretUntil = *cfg.LegacySecretUntil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(cfg.KeysDir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list JWT keys: %w", err)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		id := strings.TrimSuffix(filepath.Base(path), ".pem")
+		key, err := loadTokenKey(id, path)
+		if err != nil {
+			return nil, err
+		}
+		set.keys[id] = key
+	}
+
+	active, ok := set.keys[cfg.ActiveKeyID]
+	if !ok {
+		return nil, fmt.Errorf("active JWT key %q not found in %s", cfg.ActiveKeyID, cfg.KeysDir)
+	}
+	if active.privateKey == nil {
+		return nil, fmt.Errorf("active JWT key %q has no private key", cfg.ActiveKeyID)
+	}
+	set.active = active
+
+	return set, nil
+}
+
+// Sign signs the claims with the active key, or with the HS256 secret if no asymmetric key is configured
+func (s *TokenKeySet) Sign(claims jwt.Claims) (string, error) {
+	if s.active == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	}
+
+	token := jwt.NewWithClaims(s.active.method, claims)
+	token.Header["kid"] = s.active.id
+	return token.SignedString(s.active.privateKey)
+}
+
+// Keyfunc returns the key that verifies a parsed token, chosen by its kid header.
+// The token's algorithm must match the key, so a public key can never be used as an HMAC secret.
+func (s *TokenKeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	if kid == "" {
+		if !s.acceptsSecret(time.Now()) {
+			return nil, fmt.Errorf("token has no key id")
+		}
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.secret, nil
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.publicKey, nil
+}
+
+// acceptsSecret reports whether tokens without a kid are verified with the HS256 secret at the given time:
+// always when it is the only key, and before the legacy cutoff when asymmetric keys are configured
+func (s *TokenKeySet) acceptsSecret(now time.Time) bool {
+	if s.secret == nil {
+		return false
+	}
+	if s.active == nil {
+		return true
+	}
+	return now.Before(s.secretUntil)
+}
+
+// JWKS returns the public verification keys in JWK Set format. The HS256 secret is never published.
+func (s *TokenKeySet) JWKS() *models.JSONWebKeySet {
+	ids := make([]string, 0, len(s.keys))
+	for id := range s.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := &models.JSONWebKeySet{Keys: make([]models.JSONWebKey, 0, len(ids))}
+	for _, id := range ids {
+		set.Keys = append(set.Keys, s.keys[id].jwk())
+	}
+	return set
+}
+
+// jwk returns the public part of the key in JWK format
+func (k *tokenKey) jwk() models.JSONWebKey {
+	jwk := models.JSONWebKey{
+		KeyID:     k.id,
+		Use:       "sig",
+		Algorithm: k.method.Alg(),
+	}
+
+	switch pub := k.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+
+	return jwk
+}
+
+// loadTokenKey reads a PEM encoded RSA or Ed25519 key, private (PKCS#8 or PKCS#1) or public (PKIX)
+func loadTokenKey(id, path string) (*tokenKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT key %q: %w", id, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("JWT key %q is not PEM encoded", id)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("JWT key %q has unsupported PEM type %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT key %q: %w", id, err)
+	}
+
+	key := &tokenKey{id: id}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.privateKey, key.publicKey = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.publicKey = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.privateKey, key.publicKey = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.publicKey = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("JWT key %q must be an RSA or Ed25519 key", id)
+	}
+
+	if pub, ok := key.publicKey.(*rsa.PublicKey); ok && pub.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("JWT key %q is shorter than %d bits", id, minRSAKeyBits)
+	}
+
+	return key, nil
+}
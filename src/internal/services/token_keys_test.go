@@ -0,0 +1,87 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"easy-queue-go/src/internal/config"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testJWTSecret = "test-secret"
+
+// newSigningKeySet creates a key set with an Ed25519 signing key, the HS256 secret and the given legacy cutoff
+func newSigningKeySet(t *testing.T, legacySecretUntil *time.Time) *TokenKeySet {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "2026-01.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	set, err := LoadTokenKeySet(&config.JWTConfig{
+		Secret:            testJWTSecret,
+		KeysDir:           dir,
+		ActiveKeyID:       "2026-01",
+		LegacySecretUntil: legacySecretUntil,
+	})
+	if err != nil {
+		t.Fatalf("failed to load key set: %v", err)
+	}
+	return set
+}
+
+// parseLegacyToken verifies a token signed with the HS256 secret and no kid
+func parseLegacyToken(t *testing.T, set *TokenKeySet) error {
+	t.Helper()
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "user"}).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	_, err = jwt.Parse(signed, set.Keyfunc)
+	return err
+}
+
+func TestKeyfuncRejectsTokensWithoutKidWithoutLegacyCutoff(t *testing.T) {
+	if err := parseLegacyToken(t, newSigningKeySet(t, nil)); err == nil {
+		t.Fatal("expected a token without kid to be rejected")
+	}
+}
+
+func TestKeyfuncAcceptsTokensWithoutKidUntilLegacyCutoff(t *testing.T) {
+	until := time.Now().Add(time.Hour)
+	if err := parseLegacyToken(t, newSigningKeySet(t, &until)); err != nil {
+		t.Fatalf("expected a token without kid to be accepted before the cutoff, got %v", err)
+	}
+
+	passed := time.Now().Add(-time.Hour)
+	if err := parseLegacyToken(t, newSigningKeySet(t, &passed)); err == nil {
+		t.Fatal("expected a token without kid to be rejected after the cutoff")
+	}
+}
+
+func TestKeyfuncAcceptsTokensWithoutKidWhenSecretIsTheOnlyKey(t *testing.T) {
+	set, err := LoadTokenKeySet(&config.JWTConfig{Secret: testJWTSecret})
+	if err != nil {
+		t.Fatalf("failed to load key set: %v", err)
+	}
+
+	if err := parseLegacyToken(t, set); err != nil {
+		t.Fatalf("expected a token signed with the secret to be accepted, got %v", err)
+	}
+}
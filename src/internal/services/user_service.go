@@ -6,6 +6,7 @@ import (
 	"easy-queue-go/src/internal/models"
 	"easy-queue-go/src/internal/repositories"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
@@ -20,24 +21,35 @@ var tracer = otel.Tracer("user-service")
 // UserService defines the interface for user business operations
 type UserService interface {
 	CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, error)
+	CreateUserAsAdmin(ctx context.Context, req *models.AdminCreateUserRequest) (*models.UserResponse, error)
+	BootstrapAdmin(ctx context.Context, req *models.AdminCreateUserRequest) error
 	GetUserByID(ctx context.Context, id uuid.UUID) (*models.UserResponse, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.UserResponse, error)
 	ListAllUsers(ctx context.Context) ([]*models.UserResponse, error)
+	UpdateProfile(ctx context.Context, id uuid.UUID, req *models.UpdateProfileRequest) (*models.UserResponse, error)
+	SetUserActive(ctx context.Context, id uuid.UUID, active bool) (*models.UserResponse, error)
+	AddUserRole(ctx context.Context, id uuid.UUID, role models.UserRole) (*models.UserResponse, error)
+	RemoveUserRole(ctx context.Context, id uuid.UUID, role models.UserRole) (*models.UserResponse, error)
+	DeleteUser(ctx context.Context, id uuid.UUID) error
 }
 
 // userService implements UserService
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo          repositories.UserRepository
+	refreshTokenRepo  repositories.RefreshTokenRepository
+	phoneVerification PhoneVerificationService
 }
 
 // NewUserService creates a new instance of UserService
-func NewUserService(userRepo repositories.UserRepository) UserService {
+func NewUserService(userRepo repositories.UserRepository, refreshTokenRepo repositories.RefreshTokenRepository, phoneVerification PhoneVerificationService) UserService {
 	return &userService{
-		userRepo: userRepo,
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		phoneVerification: phoneVerification,
 	}
 }
 
-// CreateUser creates a new user with business validations
+// CreateUser registers a new user with business validations. The admin role cannot be self-assigned.
 func (s *userService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, error) {
 	ctx, span := tracer.Start(ctx, "UserService.CreateUser",
 		trace.WithAttributes(
@@ -46,6 +58,71 @@ func (s *userService) CreateUser(ctx context.Context, req *models.CreateUserRequ
 	)
 	defer span.End()
 
+	for _, role := range req.Roles {
+		if role == models.RoleAdmin {
+			log.Warn(ctx, "Registration requested the admin role", zap.String("email", req.Email))
+			return nil, fmt.Errorf("admin role cannot be self-assigned")
+		}
+	}
+
+	return s.createUser(ctx, req)
+}
+
+// CreateUserAsAdmin creates a user on behalf of an admin, who may grant any role
+func (s *userService) CreateUserAsAdmin(ctx context.Context, req *models.AdminCreateUserRequest) (*models.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateUserAsAdmin",
+		trace.WithAttributes(
+			attribute.String("email", req.Email),
+		),
+	)
+	defer span.End()
+
+	return s.createUser(ctx, req.CreateUserRequest())
+}
+
+// BootstrapAdmin creates the initial administrator if the system has no active admin yet.
+// An existing account with the same email is never promoted, since anyone could have registered it.
+func (s *userService) BootstrapAdmin(ctx context.Context, req *models.AdminCreateUserRequest) error {
+	ctx, span := tracer.Start(ctx, "UserService.BootstrapAdmin",
+		trace.WithAttributes(
+			attribute.String("email", req.Email),
+		),
+	)
+	defer span.End()
+
+	admins, err := s.userRepo.CountActiveAdmins(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if admins > 0 {
+		log.Info(ctx, "Admin already exists, skipping bootstrap admin", zap.Int("admins", admins))
+		return nil
+	}
+
+	if existing, err := s.userRepo.FindByEmail(ctx, req.Email); err == nil && existing != nil {
+		log.Warn(ctx, "Bootstrap admin email is already registered, not promoting the existing account",
+			zap.String("email", req.Email),
+			zap.String("user_id", existing.ID.String()),
+		)
+		return nil
+	}
+
+	user, err := s.createUser(ctx, req.CreateUserRequest())
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Bootstrap admin created", zap.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+// createUser validates and stores a new user with the requested roles
+func (s *userService) createUser(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, error) {
+	span := trace.SpanFromContext(ctx)
+
 	log.Info(ctx, "Creating new user",
 		zap.String("email", req.Email),
 		zap.Int("roles_count", len(req.Roles)),
@@ -88,6 +165,15 @@ func (s *userService) CreateUser(ctx context.Context, req *models.CreateUserRequ
 
 	span.SetAttributes(attribute.String("user_id", user.ID.String()))
 
+	// The account is usable without a verified phone, so a failed delivery only means
+	// the user has to request a new code
+	if err := s.phoneVerification.SendCode(ctx, user.ID); err != nil {
+		log.Warn(ctx, "Failed to send phone verification code after registration",
+			zap.Error(err),
+			zap.String("user_id", user.ID.String()),
+		)
+	}
+
 	return user.ToResponse(), nil
 }
 
@@ -165,6 +251,208 @@ func (s *userService) ListAllUsers(ctx context.Context) ([]*models.UserResponse,
 	return responses, nil
 }
 
+// UpdateProfile updates the profile of a user. A new phone number must be verified again,
+// so the codes sent to the previous one are discarded and a verification code is sent to it.
+func (s *userService) UpdateProfile(ctx context.Context, id uuid.UUID, req *models.UpdateProfileRequest) (*models.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateProfile",
+		trace.WithAttributes(
+			attribute.String("user_id", id.String()),
+		),
+	)
+	defer span.End()
+
+	phoneChanged := false
+	if req.Phone != nil {
+		changed, err := s.userRepo.UpdatePhone(ctx, id, *req.Phone, time.Now())
+		if err != nil {
+			log.Error(ctx, "Failed to update user profile",
+				zap.Error(err),
+				zap.String("user_id", id.String()),
+			)
+			span.RecordError(err)
+			return nil, err
+		}
+		phoneChanged = changed
+	}
+
+	log.Info(ctx, "User profile updated",
+		zap.String("user_id", id.String()),
+		zap.Bool("phone_changed", phoneChanged),
+	)
+
+	if phoneChanged {
+		// Codes sent to the previous number must not verify the new one
+		if err := s.phoneVerification.InvalidateCodes(ctx, id); err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+
+		if err := s.phoneVerification.SendCode(ctx, id); err != nil {
+			log.Warn(ctx, "Failed to send phone verification code after phone change",
+				zap.Error(err),
+				zap.String("user_id", id.String()),
+			)
+		}
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return user.ToResponse(), nil
+}
+
+// SetUserActive deactivates or reactivates a user account. Deactivation ends every session of the user,
+// so reactivating the account later does not bring old tokens back.
+func (s *userService) SetUserActive(ctx context.Context, id uuid.UUID, active bool) (*models.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "UserService.SetUserActive",
+		trace.WithAttributes(
+			attribute.String("user_id", id.String()),
+			attribute.Bool("active", active),
+		),
+	)
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if user.IsActive == active {
+		return user.ToResponse(), nil
+	}
+
+	now := time.Now()
+	user.IsActive = active
+	user.UpdatedAt = now
+
+	if err := s.userRepo.UpdateAccess(ctx, user); err != nil {
+		log.Error(ctx, "Failed to change user status", zap.Error(err), zap.String("user_id", id.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if !active {
+		if err := s.endSessions(ctx, id, now); err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+
+	log.Info(ctx, "User status changed",
+		zap.String("user_id", id.String()),
+		zap.Bool("active", active),
+	)
+
+	return user.ToResponse(), nil
+}
+
+// AddUserRole grants a role to a user
+func (s *userService) AddUserRole(ctx context.Context, id uuid.UUID, role models.UserRole) (*models.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "UserService.AddUserRole",
+		trace.WithAttributes(
+			attribute.String("user_id", id.String()),
+			attribute.String("role", string(role)),
+		),
+	)
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if !user.AddRole(role) {
+		return user.ToResponse(), nil
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.UpdateAccess(ctx, user); err != nil {
+		log.Error(ctx, "Failed to add user role", zap.Error(err), zap.String("user_id", id.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "User role added", zap.String("user_id", id.String()), zap.String("role", string(role)))
+
+	return user.ToResponse(), nil
+}
+
+// RemoveUserRole takes a role away from a user, who must keep at least one role
+func (s *userService) RemoveUserRole(ctx context.Context, id uuid.UUID, role models.UserRole) (*models.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "UserService.RemoveUserRole",
+		trace.WithAttributes(
+			attribute.String("user_id", id.String()),
+			attribute.String("role", string(role)),
+		),
+	)
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if !user.RemoveRole(role) {
+		return user.ToResponse(), nil
+	}
+
+	if len(user.Roles) == 0 {
+		return nil, fmt.Errorf("user must keep at least one role")
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.UpdateAccess(ctx, user); err != nil {
+		log.Error(ctx, "Failed to remove user role", zap.Error(err), zap.String("user_id", id.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	log.Info(ctx, "User role removed", zap.String("user_id", id.String()), zap.String("role", string(role)))
+
+	return user.ToResponse(), nil
+}
+
+// DeleteUser deletes a user together with their businesses, tickets and sessions
+func (s *userService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "UserService.DeleteUser",
+		trace.WithAttributes(
+			attribute.String("user_id", id.String()),
+		),
+	)
+	defer span.End()
+
+	if err := s.userRepo.Delete(ctx, id); err != nil {
+		log.Error(ctx, "Failed to delete user", zap.Error(err), zap.String("user_id", id.String()))
+		span.RecordError(err)
+		return err
+	}
+
+	log.Info(ctx, "User deleted", zap.String("user_id", id.String()))
+
+	return nil
+}
+
+// endSessions revokes the refresh tokens of a user and rejects the access tokens issued until the given time
+func (s *userService) endSessions(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, id); err != nil {
+		log.Error(ctx, "Failed to revoke refresh tokens", zap.Error(err), zap.String("user_id", id.String()))
+		return err
+	}
+
+	if err := s.userRepo.InvalidateTokens(ctx, id, at); err != nil {
+		log.Error(ctx, "Failed to invalidate access tokens", zap.Error(err), zap.String("user_id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
 // hashPassword generates a bcrypt hash of the password
 func hashPassword(password string) (string, error) {
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
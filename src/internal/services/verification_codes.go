@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// verificationCodeDigits is the length of the numeric codes sent to users
+	verificationCodeDigits = 6
+
+	// verificationResendInterval is the minimum time between two codes sent to the same user,
+	// so the code request endpoints cannot be used to flood a phone with messages
+	verificationResendInterval = time.Minute
+)
+
+// issueVerificationCode generates a new numeric code for a user and stores its hash along with the
+// phone number it is sent to, superseding previous codes for the same purpose. It returns the code to
+// deliver, or an empty string if a code was issued too recently to send another one.
+func issueVerificationCode(
+	ctx context.Context,
+	repo repositories.VerificationCodeRepository,
+	userID uuid.UUID,
+	purpose models.VerificationPurpose,
+	phone string,
+	ttl time.Duration,
+) (string, error) {
+	if latest, err := repo.FindLatest(ctx, userID, purpose); err == nil && time.Since(latest.CreatedAt) < verificationResendInterval {
+		log.Warn(ctx, "Verification code requested too soon",
+			zap.String("user_id", userID.String()),
+			zap.String("purpose", string(purpose)),
+		)
+		return "", nil
+	}
+
+	code, err := generateNumericCode(verificationCodeDigits)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash verification code: %w", err)
+	}
+
+	if err := repo.Create(ctx, models.NewVerificationCode(userID, purpose, phone, string(hash), ttl)); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// redeemVerificationCode checks a code against the latest one issued to the user, consumes it and returns it.
+// Every guess counts against the code until maxAttempts is reached; the attempt is reserved before
+// the comparison, so concurrent guesses cannot get around the limit. Every failure returns the
+// same error, so callers cannot tell an unknown code from an expired one.
+func redeemVerificationCode(
+	ctx context.Context,
+	repo repositories.VerificationCodeRepository,
+	userID uuid.UUID,
+	purpose models.VerificationPurpose,
+	code string,
+	maxAttempts int,
+) (*models.VerificationCode, error) {
+	invalid := fmt.Errorf("invalid or expired verification code")
+
+	stored, err := repo.ReserveAttempt(ctx, userID, purpose, maxAttempts, time.Now())
+	if err != nil {
+		log.Warn(ctx, "No usable verification code to redeem", zap.Error(err), zap.String("user_id", userID.String()))
+		return nil, invalid
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(stored.CodeHash), []byte(code)); err != nil {
+		log.Warn(ctx, "Wrong verification code", zap.String("user_id", userID.String()))
+		return nil, invalid
+	}
+
+	if err := repo.Consume(ctx, stored.ID, time.Now()); err != nil {
+		log.Warn(ctx, "Failed to consume verification code", zap.Error(err), zap.String("user_id", userID.String()))
+		return nil, invalid
+	}
+
+	return stored, nil
+}
+
+// verificationCodeTemplate builds the WhatsApp template message carrying a code as its only body parameter
+func verificationCodeTemplate(name, language, code string) *models.WhatsAppTemplateRequest {
+	return &models.WhatsAppTemplateRequest{
+		Name:     name,
+		Language: language,
+		Components: []models.WhatsAppTemplateComponent{
+			{
+				Type:       "body",
+				Parameters: []models.WhatsAppTemplateParameter{{Type: "text", Text: code}},
+			},
+		},
+	}
+}
+
+// generateNumericCode returns a random code of the given number of decimal digits
+func generateNumericCode(digits int) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var whatsappCommandTracer = otel.Tracer("whatsapp-command-service")
+
+// WhatsApp commands customers can send, optionally followed by a ticket number
+const (
+	whatsappCommandStatus  = "STATUS"
+	whatsappCommandCancel  = "CANCEL"
+	whatsappCommandOnMyWay = "ON MY WAY"
+	whatsappCommandHere    = "HERE"
+)
+
+// whatsappCommandHelp is the reply to messages that are not a command
+const whatsappCommandHelp = "Reply STATUS to see your place in the queue, CANCEL to cancel your ticket, " +
+	"ON MY WAY to get your estimated wait, or HERE when you arrive. " +
+	"If you have several tickets, add the ticket number, for example CANCEL 12."
+
+// whatsappCommandFailures tell customers why a ticket command failed, for the errors that are theirs to know.
+// Any other error gets whatsappCommandFailureGeneric rather than its internal message.
+var whatsappCommandFailures = map[string]string{
+	"ticket not found":                    "it was not found",
+	"ticket can no longer be cancelled":   "it has already been served or closed",
+	"ticket is no longer active":          "it is no longer active",
+	"ticket status changed concurrently":  "it was just updated, please try again",
+	"business location is not configured": "this business does not accept check-ins by location",
+}
+
+const whatsappCommandFailureGeneric = "something went wrong, please try again later or use the app"
+
+// WhatsAppCommandService answers the queue commands customers send to the WhatsApp number
+type WhatsAppCommandService interface {
+	HandleMessage(ctx context.Context, message *models.WhatsAppInboundMessage) error
+}
+
+// whatsappCommandService implements WhatsAppCommandService
+type whatsappCommandService struct {
+	userRepo      repositories.UserRepository
+	ticketService TicketService
+	notifications NotificationService
+}
+
+// NewWhatsAppCommandService creates a new instance of WhatsAppCommandService
+func NewWhatsAppCommandService(userRepo repositories.UserRepository, ticketService TicketService, notifications NotificationService) WhatsAppCommandService {
+	return &whatsappCommandService{
+		userRepo:      userRepo,
+		ticketService: ticketService,
+		notifications: notifications,
+	}
+}
+
+// whatsappCommand is a parsed inbound message
+type whatsappCommand struct {
+	name   string
+	number int // Ticket number the command applies to, 0 if not given
+}
+
+// HandleMessage runs the command in the message for the customer whose verified phone number sent it
+// and queues the reply to the sender. A location message checks the customer in, like HERE.
+// Errors are only returned when the reply could not be built or queued; customer mistakes are answered in the reply.
+func (s *whatsappCommandService) HandleMessage(ctx context.Context, message *models.WhatsAppInboundMessage) error {
+	ctx, span := whatsappCommandTracer.Start(ctx, "WhatsAppCommandService.HandleMessage",
+		trace.WithAttributes(
+			attribute.String("whatsapp.from", message.From),
+		),
+	)
+	defer span.End()
+
+	reply, ticket, err := s.reply(ctx, message)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if ticket != nil {
+		err = s.notifications.EnqueueTicketText(ctx, ticket.BusinessID, ticket.ID, message.From, reply)
+	} else {
+		err = s.notifications.EnqueueText(ctx, message.From, reply)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// reply runs the command in the message and returns the reply to send back,
+// with the ticket it is about when it concerns a single ticket
+func (s *whatsappCommandService) reply(ctx context.Context, message *models.WhatsAppInboundMessage) (string, *models.TicketResponse, error) {
+	span := trace.SpanFromContext(ctx)
+
+	customer, err := s.userRepo.FindByVerifiedPhone(ctx, message.From)
+	if err != nil {
+		if err.Error() == "user not found" {
+			log.Info(ctx, "WhatsApp command from unknown number", zap.String("from", message.From))
+			return "We could not find an account with this phone number. Verify your phone number in the app to manage your tickets over WhatsApp.", nil, nil
+		}
+		log.Error(ctx, "Failed to find user by phone", zap.Error(err))
+		return "", nil, err
+	}
+
+	if !customer.IsActive || !models.RolesGrant(customer.Roles, models.PermissionTicketManageOwn) {
+		log.Warn(ctx, "WhatsApp command from user who cannot manage tickets", zap.String("user_id", customer.ID.String()))
+		return "Your account cannot manage queue tickets.", nil, nil
+	}
+
+	if message.Latitude != nil && message.Longitude != nil {
+		span.SetAttributes(attribute.String("whatsapp.command", "LOCATION"))
+		return s.checkIn(ctx, customer.ID, &models.CheckInRequest{Latitude: message.Latitude, Longitude: message.Longitude})
+	}
+
+	command := parseWhatsAppCommand(message.Text)
+	span.SetAttributes(attribute.String("whatsapp.command", command.name))
+
+	log.Info(ctx, "Handling WhatsApp command",
+		zap.String("user_id", customer.ID.String()),
+		zap.String("command", command.name),
+		zap.Int("ticket_number", command.number),
+	)
+
+	switch command.name {
+	case whatsappCommandStatus:
+		return s.status(ctx, customer.ID)
+	case whatsappCommandCancel:
+		return s.cancel(ctx, customer.ID, command.number)
+	case whatsappCommandOnMyWay:
+		return s.onMyWay(ctx, customer.ID, command.number)
+	case whatsappCommandHere:
+		return "Please share your location (attach → Location) so we can check you in.", nil, nil
+	default:
+		return whatsappCommandHelp, nil, nil
+	}
+}
+
+// status describes the place of every active ticket of the customer
+func (s *whatsappCommandService) status(ctx context.Context, customerID uuid.UUID) (string, *models.TicketResponse, error) {
+	tickets, err := s.ticketService.GetMyTickets(ctx, customerID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(tickets) == 0 {
+		return "You have no active tickets.", nil, nil
+	}
+
+	lines := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		position, err := s.ticketService.GetTicketPosition(ctx, customerID, ticket.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		lines = append(lines, describePosition(position))
+	}
+
+	return strings.Join(lines, "\n"), onlyTicket(tickets), nil
+}
+
+// cancel cancels the selected ticket of the customer
+func (s *whatsappCommandService) cancel(ctx context.Context, customerID uuid.UUID, number int) (string, *models.TicketResponse, error) {
+	ticket, reply, err := s.selectTicket(ctx, customerID, number, whatsappCommandCancel)
+	if ticket == nil {
+		return reply, nil, err
+	}
+
+	cancelled, err := s.ticketService.CancelTicket(ctx, customerID, ticket.ID)
+	if err != nil {
+		log.Warn(ctx, "WhatsApp cancel failed", zap.Error(err), zap.String("ticket_id", ticket.ID.String()))
+		return fmt.Sprintf("Ticket #%d could not be cancelled: %s.", ticket.Number, commandFailureReason(err)), ticket, nil
+	}
+
+	if cancelled.LateCancellation {
+		return fmt.Sprintf("Ticket #%d was cancelled. It was cancelled late, which counts against your reputation.", cancelled.Number), ticket, nil
+	}
+	return fmt.Sprintf("Ticket #%d was cancelled.", cancelled.Number), ticket, nil
+}
+
+// onMyWay tells the customer on their way how long they should expect to wait
+func (s *whatsappCommandService) onMyWay(ctx context.Context, customerID uuid.UUID, number int) (string, *models.TicketResponse, error) {
+	ticket, reply, err := s.selectTicket(ctx, customerID, number, whatsappCommandOnMyWay)
+	if ticket == nil {
+		return reply, nil, err
+	}
+
+	position, err := s.ticketService.GetTicketPosition(ctx, customerID, ticket.ID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return describePosition(position) + "\nReply HERE and share your location when you arrive.", ticket, nil
+}
+
+// checkIn checks in every active ticket of the customer at the shared location
+func (s *whatsappCommandService) checkIn(ctx context.Context, customerID uuid.UUID, req *models.CheckInRequest) (string, *models.TicketResponse, error) {
+	tickets, err := s.ticketService.GetMyTickets(ctx, customerID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(tickets) == 0 {
+		return "You have no active tickets to check in.", nil, nil
+	}
+
+	lines := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		result, err := s.ticketService.CheckIn(ctx, customerID, ticket.ID, req)
+		switch {
+		case err != nil:
+			log.Warn(ctx, "WhatsApp check-in failed", zap.Error(err), zap.String("ticket_id", ticket.ID.String()))
+			lines = append(lines, fmt.Sprintf("Ticket #%d could not be checked in: %s.", ticket.Number, commandFailureReason(err)))
+		case result.Status == models.TicketCheckInAccepted:
+			lines = append(lines, fmt.Sprintf("Ticket #%d: you are checked in.", ticket.Number))
+		default:
+			lines = append(lines, fmt.Sprintf("Ticket #%d: you are %.0f m away, please check in again within %d m.",
+				ticket.Number, result.DistanceMeters, result.RadiusMeters))
+		}
+	}
+
+	return strings.Join(lines, "\n"), onlyTicket(tickets), nil
+}
+
+// commandFailureReason returns the wording a customer is told for an error of a ticket command
+func commandFailureReason(err error) string {
+	if reason, ok := whatsappCommandFailures[err.Error()]; ok {
+		return reason
+	}
+	return whatsappCommandFailureGeneric
+}
+
+// onlyTicket returns the ticket of a customer who has a single one, so a reply about it is linked to it
+func onlyTicket(tickets []*models.TicketResponse) *models.TicketResponse {
+	if len(tickets) == 1 {
+		return tickets[0]
+	}
+	return nil
+}
+
+// selectTicket picks the active ticket a command applies to: the one with the given number, or the only one.
+// When no ticket can be picked it returns a nil ticket and the reply explaining why.
+func (s *whatsappCommandService) selectTicket(ctx context.Context, customerID uuid.UUID, number int, command string) (*models.TicketResponse, string, error) {
+	tickets, err := s.ticketService.GetMyTickets(ctx, customerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(tickets) == 0 {
+		return nil, "You have no active tickets.", nil
+	}
+
+	if number == 0 {
+		if len(tickets) == 1 {
+			return tickets[0], "", nil
+		}
+
+		numbers := make([]string, len(tickets))
+		for i, ticket := range tickets {
+			numbers[i] = fmt.Sprintf("#%d", ticket.Number)
+		}
+		return nil, fmt.Sprintf("You have several active tickets (%s). Reply %s followed by the ticket number, for example %s %d.",
+			strings.Join(numbers, ", "), command, command, tickets[0].Number), nil
+	}
+
+	var selected *models.TicketResponse
+	for _, ticket := range tickets {
+		if ticket.Number == number {
+			if selected != nil {
+				return nil, fmt.Sprintf("You have several active tickets #%d. Please use the app to choose one.", number), nil
+			}
+			selected = ticket
+		}
+	}
+
+	if selected == nil {
+		return nil, fmt.Sprintf("You have no active ticket #%d.", number), nil
+	}
+	return selected, "", nil
+}
+
+// describePosition summarizes the place of a ticket in its queue
+func describePosition(position *models.TicketPositionResponse) string {
+	switch position.Ticket.Status {
+	case models.TicketStatusCalled:
+		return fmt.Sprintf("Ticket #%d has been called, please go to the counter.", position.Ticket.Number)
+	case models.TicketStatusInService:
+		return fmt.Sprintf("Ticket #%d is being served.", position.Ticket.Number)
+	case models.TicketStatusSkipped:
+		return fmt.Sprintf("Ticket #%d was skipped, please ask at the counter to be called again.", position.Ticket.Number)
+	}
+	return fmt.Sprintf("Ticket #%d is number %d in line, with an estimated wait of %d minutes.",
+		position.Ticket.Number, position.Position, position.EstimatedWaitMinutes)
+}
+
+// parseWhatsAppCommand normalizes a text message into a command and an optional trailing ticket number,
+// so "on my way!" and "Cancel #12" are understood
+func parseWhatsAppCommand(text string) whatsappCommand {
+	fields := strings.Fields(strings.ToUpper(strings.Trim(strings.TrimSpace(text), ".!?")))
+
+	command := whatsappCommand{}
+	if n := len(fields); n > 1 {
+		if number, err := strconv.Atoi(strings.TrimPrefix(fields[n-1], "#")); err == nil && number > 0 {
+			command.number = number
+			fields = fields[:n-1]
+		}
+	}
+
+	command.name = strings.Join(fields, " ")
+	return command
+}
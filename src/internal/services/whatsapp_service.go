@@ -3,15 +3,22 @@ package services
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"easy-queue-go/src/internal/config"
 	"easy-queue-go/src/internal/log"
 	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
@@ -19,24 +26,66 @@ import (
 
 var whatsappTracer = otel.Tracer("whatsapp-service")
 
+// businessMessageListLimit is the number of messages returned when listing the messages of a business
+const businessMessageListLimit = 200
+
+// WhatsAppAPIError is returned when the Graph API rejects a message
+type WhatsAppAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error implements error
+func (e *WhatsAppAPIError) Error() string {
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Permanent reports whether sending the same message again cannot succeed, e.g. for an invalid recipient,
+// a template that is not approved or a closed 24-hour window. Rate limiting (429) passes, and so does an
+// expired token (401), which the token manager replaces.
+func (e *WhatsAppAPIError) Permanent() bool {
+	return e.StatusCode >= 400 && e.StatusCode < 500 &&
+		e.StatusCode != http.StatusTooManyRequests && e.StatusCode != http.StatusUnauthorized
+}
+
 // WhatsAppService defines the interface for WhatsApp operations
 type WhatsAppService interface {
 	SendTextMessage(ctx context.Context, to, message string) (*models.WhatsAppMessageResponse, error)
 	SendTemplateMessage(ctx context.Context, to string, template *models.WhatsAppTemplateRequest) (*models.WhatsAppMessageResponse, error)
 	SendMessage(ctx context.Context, req *models.SendWhatsAppMessageRequest) (*models.WhatsAppMessageResponse, error)
 	VerifyWebhook(mode, token, challenge string) (string, error)
+	VerifyWebhookSignature(body []byte, signature string) error
 	ProcessWebhook(ctx context.Context, payload *models.WhatsAppWebhookPayload) error
+	GetMessage(ctx context.Context, messageID string) (*models.WhatsAppMessage, error)
+	LinkMessage(ctx context.Context, messageID string, businessID, ticketID *uuid.UUID) error
+	ListBusinessMessages(ctx context.Context, businessID uuid.UUID, ticketID *uuid.UUID) ([]*models.WhatsAppMessage, error)
+	GetTokenStatus() models.WhatsAppTokenStatus
 }
 
 type whatsappService struct {
-	config     *config.WhatsAppConfig
-	httpClient *http.Client
+	config      *config.WhatsAppConfig
+	messageRepo repositories.WhatsAppMessageRepository
+	commands    WhatsAppCommandService
+	tokens      *WhatsAppTokenManager
+	httpClient  *http.Client
 }
 
-// NewWhatsAppService creates a new WhatsApp service instance
-func NewWhatsAppService(config *config.WhatsAppConfig) WhatsAppService {
+// NewWhatsAppService creates a new WhatsApp service instance.
+// Every message accepted by the Graph API is logged in messageRepo, together with the delivery
+// statuses Meta reports for it through the webhook.
+// Inbound text and location messages are passed to commands, which replies to the customer.
+// Requests to the Graph API are authenticated with the current token of tokens.
+func NewWhatsAppService(
+	config *config.WhatsAppConfig,
+	messageRepo repositories.WhatsAppMessageRepository,
+	commands WhatsAppCommandService,
+	tokens *WhatsAppTokenManager,
+) WhatsAppService {
 	return &whatsappService{
-		config: config,
+		config:      config,
+		messageRepo: messageRepo,
+		commands:    commands,
+		tokens:      tokens,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -91,7 +140,7 @@ func (s *whatsappService) SendTextMessage(ctx context.Context, to, message strin
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.AccessToken))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.tokens.GetToken()))
 
 	// Send request
 	resp, err := s.httpClient.Do(req)
@@ -120,7 +169,7 @@ func (s *whatsappService) SendTextMessage(ctx context.Context, to, message strin
 			Type:    "text",
 			SentAt:  time.Now(),
 			Error:   fmt.Sprintf("API error: %s", string(body)),
-		}, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		}, &WhatsAppAPIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	// Parse response
@@ -144,6 +193,8 @@ func (s *whatsappService) SendTextMessage(ctx context.Context, to, message strin
 		SentAt:    time.Now(),
 	}
 
+	s.recordMessage(ctx, models.NewWhatsAppMessage(messageID, to, models.WhatsAppMessageTypeText, ""))
+
 	log.Info(ctx, "WhatsApp message sent successfully",
 		zap.String("message_id", messageID),
 		zap.String("to", to),
@@ -214,7 +265,7 @@ func (s *whatsappService) SendTemplateMessage(ctx context.Context, to string, te
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.AccessToken))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.tokens.GetToken()))
 
 	// Send request
 	resp, err := s.httpClient.Do(req)
@@ -243,7 +294,7 @@ func (s *whatsappService) SendTemplateMessage(ctx context.Context, to string, te
 			Type:    "template",
 			SentAt:  time.Now(),
 			Error:   fmt.Sprintf("API error: %s", string(body)),
-		}, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		}, &WhatsAppAPIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	// Parse response
@@ -267,6 +318,8 @@ func (s *whatsappService) SendTemplateMessage(ctx context.Context, to string, te
 		SentAt:    time.Now(),
 	}
 
+	s.recordMessage(ctx, models.NewWhatsAppMessage(messageID, to, models.WhatsAppMessageTypeTemplate, template.Name))
+
 	log.Info(ctx, "WhatsApp template message sent successfully",
 		zap.String("message_id", messageID),
 		zap.String("to", to),
@@ -325,7 +378,42 @@ func (s *whatsappService) VerifyWebhook(mode, token, challenge string) (string,
 	return challenge, nil
 }
 
-// ProcessWebhook processes incoming webhook events from WhatsApp
+// webhookSignaturePrefix precedes the hex encoded HMAC in the X-Hub-Signature-256 header
+const webhookSignaturePrefix = "sha256="
+
+// VerifyWebhookSignature checks the X-Hub-Signature-256 header Meta sends with every webhook event:
+// an HMAC-SHA256 of the raw request body keyed with the app secret. Without an app secret no
+// event can be authenticated, so every event is rejected.
+func (s *whatsappService) VerifyWebhookSignature(body []byte, signature string) error {
+	if s.config.AppSecret == "" {
+		return fmt.Errorf("webhook app secret is not configured")
+	}
+
+	if signature == "" {
+		return fmt.Errorf("missing webhook signature")
+	}
+
+	if !strings.HasPrefix(signature, webhookSignaturePrefix) {
+		return fmt.Errorf("invalid webhook signature")
+	}
+
+	received, err := hex.DecodeString(strings.TrimPrefix(signature, webhookSignaturePrefix))
+	if err != nil {
+		return fmt.Errorf("invalid webhook signature")
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.config.AppSecret))
+	mac.Write(body)
+	if !hmac.Equal(received, mac.Sum(nil)) {
+		return fmt.Errorf("invalid webhook signature")
+	}
+
+	return nil
+}
+
+// ProcessWebhook processes incoming webhook events from WhatsApp.
+// Failures are logged rather than returned: an error makes Meta redeliver the whole payload,
+// which would answer its inbound messages again.
 func (s *whatsappService) ProcessWebhook(ctx context.Context, payload *models.WhatsAppWebhookPayload) error {
 	ctx, span := whatsappTracer.Start(ctx, "whatsapp.ProcessWebhook")
 	defer span.End()
@@ -357,11 +445,144 @@ func (s *whatsappService) ProcessWebhook(ctx context.Context, payload *models.Wh
 					attribute.String("whatsapp.type", message.Type),
 				)
 
-				// TODO: Process the message (e.g., send to queue, trigger business logic)
-				// For now, just log it
+				s.answerMessage(ctx, message.From, message.Type, message.Text.Body, message.Location.Latitude, message.Location.Longitude)
+			}
+
+			// Process the delivery status of each outbound message
+			for _, status := range change.Value.Statuses {
+				update, err := parseStatusUpdate(status.ID, status.RecipientID, status.Status, status.Timestamp)
+				if err != nil {
+					log.Warn(ctx, "Ignoring invalid WhatsApp status", zap.Error(err), zap.String("message_id", status.ID))
+					continue
+				}
+
+				if len(status.Errors) > 0 {
+					update.ErrorCode = &status.Errors[0].Code
+					update.ErrorTitle = &status.Errors[0].Title
+				}
+
+				log.Info(ctx, "Received WhatsApp message status",
+					zap.String("message_id", update.MessageID),
+					zap.String("status", string(update.Status)),
+				)
+
+				if err := s.messageRepo.ApplyStatus(ctx, update); err != nil {
+					log.Error(ctx, "Failed to store WhatsApp message status", zap.Error(err), zap.String("message_id", update.MessageID))
+					span.RecordError(err)
+					continue
+				}
 			}
 		}
 	}
 
 	return nil
 }
+
+// GetMessage returns the log entry of an outbound message with its latest delivery status
+func (s *whatsappService) GetMessage(ctx context.Context, messageID string) (*models.WhatsAppMessage, error) {
+	ctx, span := whatsappTracer.Start(ctx, "whatsapp.GetMessage")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("whatsapp.message_id", messageID))
+
+	message, err := s.messageRepo.FindByID(ctx, messageID)
+	if err != nil {
+		log.Warn(ctx, "Failed to find WhatsApp message", zap.Error(err), zap.String("message_id", messageID))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// LinkMessage records the business and ticket a sent message is about
+func (s *whatsappService) LinkMessage(ctx context.Context, messageID string, businessID, ticketID *uuid.UUID) error {
+	if err := s.messageRepo.Link(ctx, messageID, businessID, ticketID); err != nil {
+		log.Error(ctx, "Failed to link WhatsApp message", zap.Error(err), zap.String("message_id", messageID))
+		return err
+	}
+	return nil
+}
+
+// ListBusinessMessages returns the latest messages about a business with their delivery status,
+// optionally only those about one of its tickets
+func (s *whatsappService) ListBusinessMessages(ctx context.Context, businessID uuid.UUID, ticketID *uuid.UUID) ([]*models.WhatsAppMessage, error) {
+	ctx, span := whatsappTracer.Start(ctx, "whatsapp.ListBusinessMessages")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("business_id", businessID.String()))
+
+	messages, err := s.messageRepo.FindByBusiness(ctx, businessID, ticketID, businessMessageListLimit)
+	if err != nil {
+		log.Error(ctx, "Failed to list WhatsApp messages of business", zap.Error(err), zap.String("business_id", businessID.String()))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("message_count", len(messages)))
+
+	return messages, nil
+}
+
+// GetTokenStatus returns the status of the access token used to call the Graph API
+func (s *whatsappService) GetTokenStatus() models.WhatsAppTokenStatus {
+	return s.tokens.Status()
+}
+
+// recordMessage logs a message accepted by the Graph API. The message was already sent,
+// so a failure to log it is not returned to the caller.
+func (s *whatsappService) recordMessage(ctx context.Context, message *models.WhatsAppMessage) {
+	if message.ID == "" {
+		return
+	}
+
+	if err := s.messageRepo.Create(ctx, message); err != nil {
+		log.Error(ctx, "Failed to log WhatsApp message", zap.Error(err), zap.String("message_id", message.ID))
+	}
+}
+
+// parseStatusUpdate validates a status callback; Meta sends its timestamp as Unix seconds in a string
+func parseStatusUpdate(messageID, recipient, status, timestamp string) (*models.WhatsAppStatusUpdate, error) {
+	if messageID == "" {
+		return nil, fmt.Errorf("status without message id")
+	}
+
+	update := &models.WhatsAppStatusUpdate{
+		MessageID: messageID,
+		Recipient: recipient,
+		Status:    models.WhatsAppMessageStatus(status),
+	}
+
+	if !update.Status.IsValid() {
+		return nil, fmt.Errorf("unknown message status: %s", status)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid status timestamp: %s", timestamp)
+	}
+	update.Timestamp = time.Unix(seconds, 0)
+
+	return update, nil
+}
+
+// answerMessage passes an inbound message to the command service, which replies to its sender.
+// Failures are only logged: returning them would make Meta redeliver the message and run the command twice.
+func (s *whatsappService) answerMessage(ctx context.Context, from, messageType, text string, latitude, longitude float64) {
+	inbound := &models.WhatsAppInboundMessage{From: from}
+
+	switch messageType {
+	case "text":
+		inbound.Text = text
+	case "location":
+		inbound.Latitude = &latitude
+		inbound.Longitude = &longitude
+	default:
+		log.Info(ctx, "Ignoring WhatsApp message type", zap.String("from", from), zap.String("type", messageType))
+		return
+	}
+
+	if err := s.commands.HandleMessage(ctx, inbound); err != nil {
+		log.Error(ctx, "Failed to handle WhatsApp command", zap.Error(err), zap.String("from", from))
+	}
+}
@@ -2,7 +2,12 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"easy-queue-go/src/internal/config"
 	"easy-queue-go/src/internal/log"
+	"easy-queue-go/src/internal/models"
+	"easy-queue-go/src/internal/repositories"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,16 +18,33 @@ import (
 	"go.uber.org/zap"
 )
 
-// WhatsAppTokenManager manages access token refresh and validation
+// Sources of the access token in use
+const (
+	whatsappTokenSourceConfig = "config" // WHATSAPP_ACCESS_TOKEN
+	whatsappTokenSourceStored = "stored" // Extended from WHATSAPP_ACCESS_TOKEN and persisted
+)
+
+// WhatsAppTokenManager manages access token refresh and validation.
+// Tokens obtained by extending the configured one are persisted, so a restart keeps using them instead of
+// the configured token, which may have expired meanwhile. Without an app ID and secret the token cannot be
+// validated or extended, and the configured token is used as is.
 type WhatsAppTokenManager struct {
 	currentToken  string
+	seedHash      string
+	source        string
 	tokenExpiry   time.Time
+	lastRefresh   time.Time
+	lastError     string
+	phoneNumberID string
+	apiURL        string
 	appID         string
 	appSecret     string
+	tokenRepo     repositories.WhatsAppTokenRepository
 	httpClient    *http.Client
 	mu            sync.RWMutex
 	refreshTicker *time.Ticker
 	stopChan      chan struct{}
+	wg            sync.WaitGroup
 }
 
 // TokenDebugResponse represents the response from token debug endpoint
@@ -37,12 +59,18 @@ type TokenDebugResponse struct {
 	} `json:"data"`
 }
 
-// NewWhatsAppTokenManager creates a new token manager
-func NewWhatsAppTokenManager(initialToken, appID, appSecret string) *WhatsAppTokenManager {
+// NewWhatsAppTokenManager creates a new token manager starting from the configured access token.
+// Extended tokens are stored in tokenRepo.
+func NewWhatsAppTokenManager(config *config.WhatsAppConfig, tokenRepo repositories.WhatsAppTokenRepository) *WhatsAppTokenManager {
 	return &WhatsAppTokenManager{
-		currentToken: initialToken,
-		appID:        appID,
-		appSecret:    appSecret,
+		currentToken:  config.AccessToken,
+		seedHash:      hashWhatsAppToken(config.AccessToken),
+		source:        whatsappTokenSourceConfig,
+		phoneNumberID: config.PhoneNumberID,
+		apiURL:        config.APIURL,
+		appID:         config.AppID,
+		appSecret:     config.AppSecret,
+		tokenRepo:     tokenRepo,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
@@ -50,29 +78,44 @@ func NewWhatsAppTokenManager(initialToken, appID, appSecret string) *WhatsAppTok
 	}
 }
 
-// Start begins the automatic token refresh process
+// Start loads the stored token and begins the automatic token refresh process
 func (tm *WhatsAppTokenManager) Start(ctx context.Context) error {
 	log.Info(ctx, "Starting WhatsApp token manager")
 
+	tm.loadStoredToken(ctx)
+
+	if !tm.managed() {
+		log.Warn(ctx, "WhatsApp app ID or secret not configured - access token will not be refreshed")
+		return nil
+	}
+
 	// Validate current token and get expiry
 	if err := tm.validateAndUpdateExpiry(ctx); err != nil {
 		log.Warn(ctx, "Failed to validate initial token", zap.Error(err))
+		tm.setLastError(err)
+	}
+
+	// Extend a token that expires soon right away instead of at the first tick
+	if err := tm.checkAndRefresh(ctx); err != nil {
+		log.Error(ctx, "Failed to refresh token", zap.Error(err))
 	}
 
 	// Start refresh ticker (check every 6 hours)
 	tm.refreshTicker = time.NewTicker(6 * time.Hour)
 
+	tm.wg.Add(1)
 	go tm.refreshLoop(ctx)
 
 	return nil
 }
 
-// Stop stops the token manager
+// Stop stops the token manager and waits for an in-flight refresh to finish
 func (tm *WhatsAppTokenManager) Stop() {
 	if tm.refreshTicker != nil {
 		tm.refreshTicker.Stop()
 	}
 	close(tm.stopChan)
+	tm.wg.Wait()
 }
 
 // GetToken returns the current valid token
@@ -82,8 +125,43 @@ func (tm *WhatsAppTokenManager) GetToken() string {
 	return tm.currentToken
 }
 
+// managed reports whether the token can be validated and extended, which requires the app credentials
+func (tm *WhatsAppTokenManager) managed() bool {
+	return tm.appID != "" && tm.appSecret != ""
+}
+
+// loadStoredToken replaces the configured token with the stored one, if it was extended from the same
+// configured token. A different configured token means the operator replaced it, so it wins.
+func (tm *WhatsAppTokenManager) loadStoredToken(ctx context.Context) {
+	stored, err := tm.tokenRepo.Find(ctx, tm.phoneNumberID)
+	if err != nil {
+		if err.Error() != "whatsapp token not found" {
+			log.Warn(ctx, "Failed to load stored WhatsApp token, using the configured one", zap.Error(err))
+		}
+		return
+	}
+
+	if stored.SeedTokenHash != tm.seedHash {
+		log.Info(ctx, "Configured WhatsApp token changed, ignoring the stored token")
+		return
+	}
+
+	tm.mu.Lock()
+	tm.currentToken = stored.AccessToken
+	tm.source = whatsappTokenSourceStored
+	tm.lastRefresh = stored.UpdatedAt
+	if stored.ExpiresAt != nil {
+		tm.tokenExpiry = *stored.ExpiresAt
+	}
+	tm.mu.Unlock()
+
+	log.Info(ctx, "Using stored WhatsApp token", zap.Time("updated_at", stored.UpdatedAt))
+}
+
 // refreshLoop continuously checks and refreshes the token
 func (tm *WhatsAppTokenManager) refreshLoop(ctx context.Context) {
+	defer tm.wg.Done()
+
 	for {
 		select {
 		case <-tm.refreshTicker.C:
@@ -93,6 +171,9 @@ func (tm *WhatsAppTokenManager) refreshLoop(ctx context.Context) {
 		case <-tm.stopChan:
 			log.Info(ctx, "Stopping token manager")
 			return
+		case <-ctx.Done():
+			log.Info(ctx, "Token manager context cancelled")
+			return
 		}
 	}
 }
@@ -111,6 +192,7 @@ func (tm *WhatsAppTokenManager) checkAndRefresh(ctx context.Context) error {
 
 		if err := tm.extendToken(ctx); err != nil {
 			log.Error(ctx, "Failed to extend token", zap.Error(err))
+			tm.setLastError(err)
 			return err
 		}
 	}
@@ -124,8 +206,8 @@ func (tm *WhatsAppTokenManager) validateAndUpdateExpiry(ctx context.Context) err
 	token := tm.currentToken
 	tm.mu.RUnlock()
 
-	url := fmt.Sprintf("https://graph.facebook.com/debug_token?input_token=%s&access_token=%s|%s",
-		token, tm.appID, tm.appSecret)
+	url := fmt.Sprintf("%s/debug_token?input_token=%s&access_token=%s|%s",
+		tm.apiURL, token, tm.appID, tm.appSecret)
 
 	resp, err := tm.httpClient.Get(url)
 	if err != nil {
@@ -159,20 +241,21 @@ func (tm *WhatsAppTokenManager) validateAndUpdateExpiry(ctx context.Context) err
 		tm.tokenExpiry = time.Now().Add(100 * 365 * 24 * time.Hour)
 		log.Info(ctx, "Token is permanent (never expires)")
 	}
+	tm.lastError = ""
 	tm.mu.Unlock()
 
 	return nil
 }
 
-// extendToken extends the current token's lifetime
+// extendToken extends the current token's lifetime and stores the new token
 func (tm *WhatsAppTokenManager) extendToken(ctx context.Context) error {
 	tm.mu.RLock()
 	token := tm.currentToken
 	tm.mu.RUnlock()
 
 	// Exchange short-lived token for long-lived token
-	url := fmt.Sprintf("https://graph.facebook.com/oauth/access_token?grant_type=fb_exchange_token&client_id=%s&client_secret=%s&fb_exchange_token=%s",
-		tm.appID, tm.appSecret, token)
+	url := fmt.Sprintf("%s/oauth/access_token?grant_type=fb_exchange_token&client_id=%s&client_secret=%s&fb_exchange_token=%s",
+		tm.apiURL, tm.appID, tm.appSecret, token)
 
 	resp, err := tm.httpClient.Get(url)
 	if err != nil {
@@ -199,28 +282,78 @@ func (tm *WhatsAppTokenManager) extendToken(ctx context.Context) error {
 		return fmt.Errorf("no access token in response: %s", string(body))
 	}
 
+	now := time.Now()
+
 	tm.mu.Lock()
 	tm.currentToken = result.AccessToken
+	tm.source = whatsappTokenSourceStored
+	tm.lastRefresh = now
+	tm.lastError = ""
 	if result.ExpiresIn > 0 {
-		tm.tokenExpiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+		tm.tokenExpiry = now.Add(time.Duration(result.ExpiresIn) * time.Second)
 	}
+	expiry := tm.tokenExpiry
 	tm.mu.Unlock()
 
 	log.Info(ctx, "Token extended successfully",
-		zap.Time("new_expiry", tm.tokenExpiry),
+		zap.Time("new_expiry", expiry),
 	)
 
+	stored := &models.WhatsAppAccessToken{
+		PhoneNumberID: tm.phoneNumberID,
+		AccessToken:   result.AccessToken,
+		SeedTokenHash: tm.seedHash,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if !expiry.IsZero() {
+		stored.ExpiresAt = &expiry
+	}
+
+	// The extended token stays in use; it is only lost on the next restart
+	if err := tm.tokenRepo.Save(ctx, stored); err != nil {
+		log.Error(ctx, "Failed to store extended token", zap.Error(err))
+	}
+
 	return nil
 }
 
-// GetTokenInfo returns information about the current token
-func (tm *WhatsAppTokenManager) GetTokenInfo() map[string]interface{} {
+// setLastError records the latest validation or refresh failure, reported in the token status
+func (tm *WhatsAppTokenManager) setLastError(err error) {
+	tm.mu.Lock()
+	tm.lastError = err.Error()
+	tm.mu.Unlock()
+}
+
+// Status returns information about the current token.
+// The token is reported valid unless it is known to be expired or the last validation or refresh failed.
+func (tm *WhatsAppTokenManager) Status() models.WhatsAppTokenStatus {
 	tm.mu.RLock()
 	defer tm.mu.RUnlock()
 
-	return map[string]interface{}{
-		"expires_at":        tm.tokenExpiry,
-		"time_until_expiry": time.Until(tm.tokenExpiry).String(),
-		"is_valid":          time.Now().Before(tm.tokenExpiry),
+	status := models.WhatsAppTokenStatus{
+		Managed:   tm.managed(),
+		Source:    tm.source,
+		Valid:     tm.lastError == "" && (tm.tokenExpiry.IsZero() || time.Now().Before(tm.tokenExpiry)),
+		LastError: tm.lastError,
+	}
+
+	if !tm.tokenExpiry.IsZero() {
+		expiry := tm.tokenExpiry
+		status.ExpiresAt = &expiry
+		status.TimeUntilExpiry = time.Until(tm.tokenExpiry).Round(time.Second).String()
 	}
+
+	if !tm.lastRefresh.IsZero() {
+		lastRefresh := tm.lastRefresh
+		status.LastRefreshedAt = &lastRefresh
+	}
+
+	return status
+}
+
+// hashWhatsAppToken returns the hex SHA-256 of a token, which identifies it without storing it
+func hashWhatsAppToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }